@@ -6,11 +6,14 @@ import (
 	"safeboxtgbot/internal/core/config"
 	"safeboxtgbot/internal/core/constants"
 	l "safeboxtgbot/internal/core/logger"
+	"safeboxtgbot/internal/core/modes"
 	d "safeboxtgbot/internal/db"
+	"safeboxtgbot/internal/feat/cleanup"
 	"safeboxtgbot/internal/feat/items"
 	"safeboxtgbot/internal/feat/notify"
 	"safeboxtgbot/internal/feat/prompt"
 	"safeboxtgbot/internal/feat/reminder"
+	"safeboxtgbot/internal/feat/tags"
 	"safeboxtgbot/internal/feat/user"
 	fsmManager "safeboxtgbot/internal/fsm"
 	"safeboxtgbot/internal/handler/commands"
@@ -23,6 +26,7 @@ import (
 
 func main() {
 	cfg := config.MustConfig()
+	modes.MustLoad(cfg.ModesPath)
 	logger := l.MustLogger(cfg.IsDebug, l.MustLoggerBot(cfg.LoggerBotToken), cfg.AdminID)
 
 	db := d.MustDB(cfg, logger)
@@ -35,37 +39,52 @@ func main() {
 	itemRepo := repo.NewItemRepo(db)
 	reminderRepo := repo.NewReminderRepo(db)
 	messageLogRepo := repo.NewMessageLogRepo(db)
+	excludedDateRepo := repo.NewExcludedDateRepo(db)
+	tagRepo := repo.NewTagRepo(db)
+	sharedBoxRepo := repo.NewSharedBoxRepo(db)
+	phraseRepo := repo.NewPhraseRepo(db)
+	messagePoolRepo := repo.NewMessagePoolRepo(db)
+	llmCallRepo := repo.NewLLMCallRepo(db)
 
 	userService := user.NewUserService(userRepo, itemRepo, messageLogRepo, sessionStore, logger)
-	itemsService := items.NewService(itemRepo, sessionStore, logger)
-	reminderScheduler := reminder.NewScheduler()
+	itemsService := items.NewService(itemRepo, sharedBoxRepo, phraseRepo, sessionStore, logger)
+	itemStatsService := items.NewStatsService(messageLogRepo, logger)
+	tagsService := tags.NewService(tagRepo, itemRepo, itemsService, sessionStore, logger)
+	exclusionService := reminder.NewExclusionService(excludedDateRepo, userService, sessionStore, logger)
+	reminderScheduler := reminder.NewScheduler().WithExclusions(exclusionService)
 	reminderService := reminder.NewService(reminderRepo, reminderScheduler, sessionStore, logger)
 
 	replies := text.NewReplies()
-	bot := b.MustBot(cfg, fsm, userService, itemsService, reminderService, replies, logger)
+	bot := b.MustBot(cfg, fsm, userService, itemsService, itemStatsService, tagsService, reminderService, exclusionService, replies, logger)
 
 	commands.MustInitCommandsHandler(bot)
 	keyboard.MustInitKeyboardHandler(bot)
 	message.MustInitMessagesHandler(bot)
 
-	llmClient := prompt.MustNewOpenRouterClient(cfg.OpenRouterModelApiKey, logger)
-	var groqFallback prompt.LLMGenerator
-	if cfg.GroqAPIKey != "" {
-		groqClient := prompt.MustNewGroqClient(cfg.GroqAPIKey, logger)
-		groqFallback = prompt.MustNewGroqService(groqClient, cfg.GroqModelName, logger)
-	}
-	llmService := prompt.MustNewLLMService(llmClient, cfg.OpenRouterModelName, groqFallback, logger)
+	llmService := prompt.MustNewLLMService(prompt.MustNewProviderChain(cfg, logger), llmCallRepo, logger)
+	llmBudget := prompt.MustNewBudget(cfg.LLMBudget, llmCallRepo, logger)
 	promptBuilder := prompt.MustNewPromptBuilder(cfg.PromptPath, logger)
-	messageGenerator := prompt.MustNewMessageGenerator(promptBuilder, llmService, logger)
+	go promptBuilder.Watch(context.Background())
+	messageGenerator := prompt.MustNewMessageGenerator(promptBuilder, llmService, llmBudget, logger)
 
-	commands.MustInitAdminCommandsHandler(bot, messageGenerator, promptBuilder, groqFallback, cfg.ForcePreviewFallback)
+	itemSuggester := prompt.MustNewItemSuggester(cfg.SuggestPromptPath, llmService, llmBudget, constants.MaxItemNameLen, logger)
+	keyboard.MustInitItemSuggestButtons(bot, itemSuggester)
 
-	notifyWorker := notify.NewWorker(userService, itemsService, messageLogRepo, messageGenerator, bot, logger)
+	commands.MustInitAdminCommandsHandler(bot, messageGenerator, promptBuilder, llmService.Fallback(), cfg.ForcePreviewFallback, llmService, llmCallRepo, cfg.LLMPrices)
+
+	messagePool := notify.NewMessagePool(messagePoolRepo)
+	notifyWorker := notify.NewWorker(userService, itemsService, tagsService, messageLogRepo, messageGenerator, messagePool, bot, logger)
 	go notifyWorker.Start(context.Background())
 
+	poolWorker := notify.NewPoolWorker(messagePool, userService, itemsService, messageGenerator, logger)
+	go poolWorker.Start(context.Background())
+
 	reminderWorker := reminder.NewWorker(reminderService, userService, messageGenerator, bot.Bot, logger)
 	go reminderWorker.Start(context.Background())
 
+	cleanupWorker := cleanup.NewWorker(itemsService, reminderService, exclusionService, logger)
+	go cleanupWorker.Start(context.Background())
+
 	logger.Info("Bot successfully started!")
 	bot.Start()
 }
@@ -3,6 +3,7 @@ module safeboxtgbot
 go 1.25
 
 require (
+	github.com/goforj/godump v1.9.0
 	github.com/ilyakaznacheev/cleanenv v1.5.0
 	github.com/looplab/fsm v1.0.3
 	github.com/revrost/go-openrouter v1.1.5
@@ -13,7 +14,6 @@ require (
 
 require (
 	github.com/BurntSushi/toml v1.2.1 // indirect
-	github.com/goforj/godump v1.9.0 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/joho/godotenv v1.5.1 // indirect
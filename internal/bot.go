@@ -8,6 +8,7 @@ import (
 	"safeboxtgbot/internal/core/logger"
 	"safeboxtgbot/internal/feat/items"
 	"safeboxtgbot/internal/feat/reminder"
+	"safeboxtgbot/internal/feat/tags"
 	"safeboxtgbot/internal/feat/user"
 	fsmManager "safeboxtgbot/internal/fsm"
 	"safeboxtgbot/internal/text"
@@ -20,13 +21,16 @@ import (
 
 type Bot struct {
 	*telebot.Bot
-	Fsm             *fsmManager.FSMState
-	UserService     *user.Service
-	ItemsService    *items.Service
-	ReminderService *reminder.Service
-	Config          *config.AppConfig
-	Replies         *text.Replies
-	Logger          logger.AppLogger
+	Fsm              *fsmManager.FSMState
+	UserService      *user.Service
+	ItemsService     *items.Service
+	ItemStatsService *items.StatsService
+	TagsService      *tags.Service
+	ReminderService  *reminder.Service
+	ExclusionService *reminder.ExclusionService
+	Config           *config.AppConfig
+	Replies          *text.Replies
+	Logger           logger.AppLogger
 }
 
 func (bot *Bot) MustSend(userID int64, what interface{}, opts ...interface{}) *telebot.Message {
@@ -87,7 +91,10 @@ func MustBot(
 	fsm *fsmManager.FSMState,
 	userService *user.Service,
 	itemsService *items.Service,
+	itemStatsService *items.StatsService,
+	tagsService *tags.Service,
 	reminderService *reminder.Service,
+	exclusionService *reminder.ExclusionService,
 	replies *text.Replies,
 	logger logger.AppLogger) *Bot {
 	bot, err := telebot.NewBot(telebot.Settings{
@@ -103,13 +110,16 @@ func MustBot(
 	}
 
 	return &Bot{
-		Bot:             bot,
-		Fsm:             fsm,
-		UserService:     userService,
-		ItemsService:    itemsService,
-		ReminderService: reminderService,
-		Config:          config,
-		Replies:         replies,
-		Logger:          logger,
+		Bot:              bot,
+		Fsm:              fsm,
+		UserService:      userService,
+		ItemsService:     itemsService,
+		ItemStatsService: itemStatsService,
+		TagsService:      tagsService,
+		ReminderService:  reminderService,
+		ExclusionService: exclusionService,
+		Config:           config,
+		Replies:          replies,
+		Logger:           logger,
 	}
 }
@@ -12,14 +12,28 @@ type AppConfig struct {
 	LoggerBotToken        string         `env:"LOGGER_BOT_TOKEN"`
 	AdminID               int64          `env:"ADMIN_ID" env-required:"true"`
 	ActivationKey         string         `env:"ACTIVATION_KEY" env-required:"true"`
-	OpenRouterModelApiKey string         `env:"OPENROUTER_API_KEY" env-required:"true"`
+	OpenRouterModelApiKey string         `env:"OPENROUTER_API_KEY"`
 	OpenRouterModelName   string         `env:"OPENROUTER_MODEL_NAME" env-default:"openrouter/auto"`
 	GroqAPIKey            string         `env:"GROQ_API_KEY"`
 	GroqModelName         string         `env:"GROQ_MODEL_NAME" env-default:"groq/compound"`
-	PromptPath            string         `env:"PROMPT_PATH" env-default:"data/prompt"`
+	LLMProviders          LLMProviders   `env:"LLM_PROVIDERS"`
+	LLMPrices             LLMPrices      `env:"LLM_PRICES"`
+	PromptPath            string         `env:"PROMPT_PATH" env-default:"data/prompts"`
+	SuggestPromptPath     string         `env:"SUGGEST_PROMPT_PATH" env-default:"data/suggest_prompt"`
+	ModesPath             string         `env:"MODES_PATH" env-default:"data/modes.json"`
 	IsDebug               bool           `env:"IS_DEBUG" env-default:"false"`
 	ForcePreviewFallback  bool           `env:"FORCE_PREVIEW_FALLBACK" env-default:"false"`
 	Database              DatabaseConfig `env-required:"true"`
+	LLMBudget             LLMBudgetConfig
+}
+
+// LLMBudgetConfig holds the daily LLM limits; zero turns a limit off.
+type LLMBudgetConfig struct {
+	DailyTokens       int64  `env:"LLM_DAILY_TOKENS" env-default:"0"`
+	DailyRequests     int64  `env:"LLM_DAILY_REQUESTS" env-default:"0"`
+	UserDailyTokens   int64  `env:"LLM_USER_DAILY_TOKENS" env-default:"0"`
+	UserDailyRequests int64  `env:"LLM_USER_DAILY_REQUESTS" env-default:"0"`
+	Timezone          string `env:"LLM_BUDGET_TIMEZONE" env-default:"Europe/Moscow"`
 }
 
 type DatabaseConfig struct {
@@ -39,5 +53,9 @@ func MustConfig() *AppConfig {
 		log.Fatal("Error reading environment variables: ", err.Error())
 	}
 
+	if config.OpenRouterModelApiKey == "" && len(config.LLMProviders) == 0 {
+		log.Fatal("Either OPENROUTER_API_KEY or LLM_PROVIDERS must be set")
+	}
+
 	return config
 }
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// LLMPrice is the price of a model in USD per million tokens.
+type LLMPrice struct {
+	Prompt     float64 `json:"prompt"`
+	Completion float64 `json:"completion"`
+}
+
+// LLMPrices maps a model name, as reported by the provider, to its price. It is read from
+// LLM_PRICES as a JSON object, e.g. {"llama3.1":{"prompt":0,"completion":0}}.
+type LLMPrices map[string]LLMPrice
+
+// SetValue implements cleanenv.Setter.
+func (p *LLMPrices) SetValue(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		*p = nil
+		return nil
+	}
+	var prices map[string]LLMPrice
+	if err := json.Unmarshal([]byte(raw), &prices); err != nil {
+		return fmt.Errorf("LLM_PRICES must be a JSON object: %w", err)
+	}
+	out := make(LLMPrices, len(prices))
+	for model, price := range prices {
+		if price.Prompt < 0 || price.Completion < 0 {
+			return fmt.Errorf("LLM_PRICES[%q]: prices must not be negative", model)
+		}
+		out[strings.TrimSpace(model)] = price
+	}
+	*p = out
+	return nil
+}
+
+// Cost estimates the price of the tokens in USD; ok is false for a model without a price.
+func (p LLMPrices) Cost(model string, promptTokens, completionTokens int64) (cost float64, ok bool) {
+	price, ok := p[model]
+	if !ok {
+		return 0, false
+	}
+	return (float64(promptTokens)*price.Prompt + float64(completionTokens)*price.Completion) / 1_000_000, true
+}
@@ -0,0 +1,29 @@
+package config
+
+import "testing"
+
+func TestLLMPricesSetValue(t *testing.T) {
+	var prices LLMPrices
+	if err := prices.SetValue(`{" gpt-4o-mini ":{"prompt":0.15,"completion":0.6},"llama3.1":{}}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cost, ok := prices.Cost("gpt-4o-mini", 1_000_000, 500_000)
+	if !ok || cost != 0.45 {
+		t.Fatalf("expected 0.45, got %f ok=%t", cost, ok)
+	}
+	if cost, ok := prices.Cost("llama3.1", 10, 10); !ok || cost != 0 {
+		t.Fatalf("expected a free model, got %f ok=%t", cost, ok)
+	}
+	if _, ok := prices.Cost("unknown", 10, 10); ok {
+		t.Fatal("expected no price for an unknown model")
+	}
+
+	if err := prices.SetValue(""); err != nil || prices != nil {
+		t.Fatalf("expected no prices, got %+v err=%v", prices, err)
+	}
+	for _, invalid := range []string{`[]`, `{"m":{"prompt":-1}}`} {
+		if err := prices.SetValue(invalid); err == nil {
+			t.Fatalf("expected error for %s", invalid)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// LLMProviderConfig describes one OpenAI-compatible chat completions endpoint,
+// e.g. Groq, a self-hosted Ollama (http://localhost:11434/v1) or llama.cpp server.
+type LLMProviderConfig struct {
+	Name           string `json:"name"`
+	BaseURL        string `json:"base_url"`
+	APIKey         string `json:"api_key"` // optional for local servers
+	Model          string `json:"model"`
+	TimeoutSeconds int    `json:"timeout_seconds"` // 0 = default
+}
+
+// LLMProviders is the ordered provider chain read from LLM_PROVIDERS as a JSON array.
+type LLMProviders []LLMProviderConfig
+
+// SetValue implements cleanenv.Setter.
+func (p *LLMProviders) SetValue(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		*p = nil
+		return nil
+	}
+	var providers []LLMProviderConfig
+	if err := json.Unmarshal([]byte(raw), &providers); err != nil {
+		return fmt.Errorf("LLM_PROVIDERS must be a JSON array: %w", err)
+	}
+	for i := range providers {
+		provider := &providers[i]
+		provider.Name = strings.TrimSpace(provider.Name)
+		provider.BaseURL = strings.TrimRight(strings.TrimSpace(provider.BaseURL), "/")
+		provider.Model = strings.TrimSpace(provider.Model)
+		if provider.BaseURL == "" || provider.Model == "" {
+			return fmt.Errorf("LLM_PROVIDERS[%d]: base_url and model are required", i)
+		}
+		if provider.TimeoutSeconds < 0 {
+			return fmt.Errorf("LLM_PROVIDERS[%d]: timeout_seconds must not be negative", i)
+		}
+		if provider.Name == "" {
+			provider.Name = provider.BaseURL
+		}
+	}
+	*p = providers
+	return nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestLLMProvidersSetValue(t *testing.T) {
+	var providers LLMProviders
+	raw := `[{"base_url":"http://localhost:11434/v1/","model":" llama3.1 ","timeout_seconds":60},
+		{"name":"groq","base_url":"https://api.groq.com/openai/v1","api_key":"k","model":"groq/compound"}]`
+	if err := providers.SetValue(raw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(providers) != 2 {
+		t.Fatalf("expected 2 providers, got %d", len(providers))
+	}
+	local := providers[0]
+	if local.BaseURL != "http://localhost:11434/v1" || local.Model != "llama3.1" || local.Name != local.BaseURL || local.TimeoutSeconds != 60 {
+		t.Fatalf("unexpected local provider: %+v", local)
+	}
+
+	if err := providers.SetValue(""); err != nil || providers != nil {
+		t.Fatalf("expected empty chain, got %+v err=%v", providers, err)
+	}
+	for _, invalid := range []string{`{"model":"x"}`, `[{"model":"x"}]`, `[{"base_url":"http://x","model":"m","timeout_seconds":-1}]`} {
+		if err := providers.SetValue(invalid); err == nil {
+			t.Fatalf("expected error for %s", invalid)
+		}
+	}
+}
@@ -9,13 +9,37 @@ const (
 	RoflMode models.UserMode = "ROFL_MODE"
 	CozyMode models.UserMode = "COZY_MODE"
 	CareMode models.UserMode = "CARE_MODE"
+
+	// MixMode and AutoMode pick one of the modes above (or from the mode registry) per nudge.
+	MixMode  models.UserMode = "MIX_MODE"
+	AutoMode models.UserMode = "AUTO_MODE"
+
+	DefaultMode = CozyMode
 )
 
 const (
-	MaxItemsPerUser    = 200
-	MaxItemNameLen     = 40
-	MaxReminderNameLen = 120
-	ReminderPrefix     = "⏰ "
+	MaxItemsPerUser         = 200
+	MaxItemNameLen          = 40
+	MaxItemNoteLen          = 200
+	MaxTagsPerUser          = 20
+	MaxTagNameLen           = 24
+	MaxSharedBoxesPerUser   = 5
+	MaxSharedBoxMembers     = 20
+	MaxSharedBoxItems       = 200
+	MaxSharedBoxNameLen     = 40
+	SharedBoxJoinCodeLen    = 8
+	MaxJoinCodeAttempts     = 5 // wrong join codes before JoinCodeLockout
+	ItemSuggestionsCount    = 8
+	MaxPhrasesPerItem       = 25
+	MaxPhraseLen            = 120
+	MaxReminderNameLen      = 120
+	MaxExcludedDatesPerUser = 100
+	MaxExclusionSkipDays    = 366
+	MaxICSImportBytes       = 256 << 10
+	MaxICSImportEvents      = 100
+	MaxItemsImportBytes     = 256 << 10
+	MaxMessageLen           = 4000 // Telegram allows 4096 characters; leaves room for HTML entities
+	ReminderPrefix          = "⏰ "
 )
 
 const (
@@ -26,6 +50,25 @@ const (
 	NotificationItemCooldownMinutes       = 360
 	ReminderWorkerIntervalSeconds         = 30
 	NonAuthSessionTTL                     = 10 * time.Minute
+	DeletionUndoWindow                    = 5 * time.Minute
+	DeletionPurgeInterval                 = 10 * time.Minute
+	JoinCodeLockout                       = 15 * time.Minute
+	LLMCircuitFailureThreshold            = 3
+	LLMCircuitCooldown                    = 2 * time.Minute
+	LLMRetryMaxAttempts                   = 3
+	LLMRetryBaseDelay                     = 500 * time.Millisecond
+	LLMRetryMaxDelay                      = 10 * time.Second
+	MessagePoolSize                       = 2
+	MessagePoolRefillInterval             = 2 * time.Minute
+	MessagePoolHorizon                    = 30 * time.Minute
+	MessagePoolMaxGenerationsPerTick      = 10
+	RepetitionHistorySize                 = 10
+	RepetitionSimilarityThreshold         = 0.6
+	RepetitionMaxAttempts                 = 3
+	LLMOutputMinLen                       = 3
+	LLMOutputMaxLen                       = 300
+	LLMOutputMaxAttempts                  = 3
+	PromptReloadInterval                  = 10 * time.Second
 )
 
 const (
@@ -43,4 +86,5 @@ const (
 var (
 	FallbackEmojis = []string{"✨", "👀", "🌿", "☕", "🤍", "🍫"}
 	WeekdayShortRu = []string{"пн", "вт", "ср", "чт", "пт", "сб", "вс"}
+	MonthNamesRu   = []string{"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь", "Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь"}
 )
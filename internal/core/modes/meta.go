@@ -0,0 +1,137 @@
+package modes
+
+import (
+	"strings"
+
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/models"
+)
+
+// AutoBucketNight stands for the hours helpers.TimeOfDay leaves empty.
+const AutoBucketNight = "night"
+
+// AutoBuckets are the time-of-day buckets of AUTO_MODE in display order.
+var AutoBuckets = []string{"morning", "day", "evening", AutoBucketNight}
+
+// metaModes pick one of the registry modes for every nudge instead of having a style of their own.
+var metaModes = []Mode{
+	{Key: constants.MixMode, Name: "Микс", Button: "🎲 Микс"},
+	{Key: constants.AutoMode, Name: "Авто", Button: "🕰 Авто"},
+}
+
+// defaultAutoMap is offered when a user switches to AUTO_MODE for the first time.
+var defaultAutoMap = map[string]models.UserMode{
+	"morning":       constants.CozyMode,
+	"day":           constants.RoflMode,
+	"evening":       constants.CareMode,
+	AutoBucketNight: constants.CareMode,
+}
+
+func Meta(key models.UserMode) (Mode, bool) {
+	for _, mode := range metaModes {
+		if mode.Key == key {
+			return mode, true
+		}
+	}
+	return Mode{}, false
+}
+
+// Choices lists the registry modes followed by the meta modes, as offered in /change_mode.
+func Choices() []Mode {
+	return append(All(), metaModes...)
+}
+
+// AutoBucket maps a helpers.TimeOfDay value to its AUTO_MODE bucket.
+func AutoBucket(timeOfDay string) string {
+	if timeOfDay == "" {
+		return AutoBucketNight
+	}
+	return timeOfDay
+}
+
+// ParseAutoMap reads "bucket=KEY" pairs separated by ";". Every bucket is present in the
+// result: missing or no longer configured keys resolve through the defaults.
+func ParseAutoMap(raw string) map[string]models.UserMode {
+	parsed := make(map[string]models.UserMode, len(AutoBuckets))
+	for _, pair := range strings.Split(raw, ";") {
+		bucket, key, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		if mode, ok := Get(models.UserMode(key)); ok {
+			parsed[bucket] = mode.Key
+		}
+	}
+	out := make(map[string]models.UserMode, len(AutoBuckets))
+	for _, bucket := range AutoBuckets {
+		if key, ok := parsed[bucket]; ok {
+			out[bucket] = key
+			continue
+		}
+		out[bucket] = Resolve(defaultAutoMap[bucket]).Key
+	}
+	return out
+}
+
+func FormatAutoMap(autoMap map[string]models.UserMode) string {
+	pairs := make([]string, 0, len(AutoBuckets))
+	for _, bucket := range AutoBuckets {
+		if key, ok := autoMap[bucket]; ok {
+			pairs = append(pairs, bucket+"="+string(key))
+		}
+	}
+	return strings.Join(pairs, ";")
+}
+
+// Next returns the registry mode after key, wrapping around; AUTO_MODE buttons cycle with it.
+func Next(key models.UserMode) models.UserMode {
+	list := registry().modes
+	for i, mode := range list {
+		if mode.Key == key {
+			return list[(i+1)%len(list)].Key
+		}
+	}
+	return list[0].Key
+}
+
+// Pick returns the registry mode for one nudge: a random one for MIX_MODE, the one mapped to
+// the time-of-day bucket for AUTO_MODE, and the user's own mode otherwise.
+func Pick(key models.UserMode, autoMap string, timeOfDay string, randIndex func(int) int) Mode {
+	switch key {
+	case constants.MixMode:
+		list := registry().modes
+		return list[randIndex(len(list))]
+	case constants.AutoMode:
+		return Resolve(ParseAutoMap(autoMap)[AutoBucket(timeOfDay)])
+	default:
+		return Resolve(key)
+	}
+}
+
+// Candidates lists every registry mode Pick can return for the user, e.g. to keep pre-generated
+// texts of all of them.
+func Candidates(key models.UserMode, autoMap string) []models.UserMode {
+	switch key {
+	case constants.MixMode:
+		list := registry().modes
+		keys := make([]models.UserMode, 0, len(list))
+		for _, mode := range list {
+			keys = append(keys, mode.Key)
+		}
+		return keys
+	case constants.AutoMode:
+		parsed := ParseAutoMap(autoMap)
+		seen := make(map[models.UserMode]struct{}, len(AutoBuckets))
+		keys := make([]models.UserMode, 0, len(AutoBuckets))
+		for _, bucket := range AutoBuckets {
+			mode := parsed[bucket]
+			if _, ok := seen[mode]; !ok {
+				seen[mode] = struct{}{}
+				keys = append(keys, mode)
+			}
+		}
+		return keys
+	default:
+		return []models.UserMode{Resolve(key).Key}
+	}
+}
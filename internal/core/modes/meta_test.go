@@ -0,0 +1,57 @@
+package modes
+
+import (
+	"testing"
+
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/models"
+)
+
+func TestParseAutoMap(t *testing.T) {
+	autoMap := ParseAutoMap("morning=ROFL_MODE;day=REMOVED_MODE;garbage")
+	want := map[string]models.UserMode{
+		"morning":       constants.RoflMode,
+		"day":           constants.RoflMode, // default for day
+		"evening":       constants.CareMode,
+		AutoBucketNight: constants.CareMode,
+	}
+	for bucket, key := range want {
+		if autoMap[bucket] != key {
+			t.Errorf("bucket %s: expected %s, got %s", bucket, key, autoMap[bucket])
+		}
+	}
+	if got := FormatAutoMap(autoMap); got != "morning=ROFL_MODE;day=ROFL_MODE;evening=CARE_MODE;night=CARE_MODE" {
+		t.Fatalf("unexpected formatted map %q", got)
+	}
+}
+
+func TestPick(t *testing.T) {
+	last := func(n int) int { return n - 1 }
+	if got := Pick(constants.MixMode, "", "day", last); got.Key != constants.CareMode {
+		t.Fatalf("expected mix to pick by index, got %s", got.Key)
+	}
+	if got := Pick(constants.AutoMode, "evening=ROFL_MODE", "evening", last); got.Key != constants.RoflMode {
+		t.Fatalf("expected auto to follow the bucket, got %s", got.Key)
+	}
+	if got := Pick(constants.AutoMode, "", "", last); got.Key != constants.CareMode {
+		t.Fatalf("expected the night default for an empty time of day, got %s", got.Key)
+	}
+	if got := Pick(constants.RoflMode, "", "day", last); got.Key != constants.RoflMode {
+		t.Fatalf("expected a plain mode to stay, got %s", got.Key)
+	}
+}
+
+func TestCandidatesAndNext(t *testing.T) {
+	if got := Candidates(constants.MixMode, ""); len(got) != 3 {
+		t.Fatalf("expected every mode for mix, got %v", got)
+	}
+	if got := Candidates(constants.AutoMode, "morning=CARE_MODE;day=CARE_MODE"); len(got) != 1 || got[0] != constants.CareMode {
+		t.Fatalf("expected the distinct mapped modes for auto, got %v", got)
+	}
+	if got := Next(constants.CareMode); got != constants.RoflMode {
+		t.Fatalf("expected Next to wrap around, got %s", got)
+	}
+	if _, err := NewRegistry([]Mode{{Key: constants.MixMode, Style: "mix", Name: "Микс"}}); err == nil {
+		t.Fatal("expected meta keys to be reserved")
+	}
+}
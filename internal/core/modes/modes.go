@@ -0,0 +1,164 @@
+package modes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdlog "log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/models"
+)
+
+// Mode is one bot style the user can pick in /change_mode.
+type Mode struct {
+	Key    models.UserMode `json:"key"`    // stored in users.mode, e.g. "ROFL_MODE"
+	Style  string          `json:"style"`  // style_mode for the LLM and prompt template name, e.g. "rofl"
+	Name   string          `json:"name"`   // display name, e.g. "Рофл"
+	Button string          `json:"button"` // button text, defaults to Name
+	Prompt string          `json:"prompt"` // style rules for styles without a <style>.tmpl prompt file
+	Emojis []string        `json:"emojis"` // fallback palette, defaults to constants.FallbackEmojis
+}
+
+// Registry is the ordered list of modes; the first one is used for unknown keys unless
+// constants.DefaultMode is present.
+type Registry struct {
+	modes []Mode
+	byKey map[models.UserMode]Mode
+}
+
+var (
+	keyPattern   = regexp.MustCompile(`^[A-Z0-9_]{1,32}$`)
+	stylePattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+	mu      sync.RWMutex
+	current = builtin()
+)
+
+// Builtin modes are used when MODES_PATH does not exist.
+func builtin() *Registry {
+	registry, err := NewRegistry([]Mode{
+		{Key: constants.RoflMode, Style: "rofl", Name: "Рофл", Button: "😜 Рофл"},
+		{Key: constants.CozyMode, Style: "cozy", Name: "Уют", Button: "🏡 Уют"},
+		{Key: constants.CareMode, Style: "care", Name: "Забота", Button: "🤍 Забота"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+func NewRegistry(list []Mode) (*Registry, error) {
+	if len(list) == 0 {
+		return nil, errors.New("no modes")
+	}
+	registry := &Registry{modes: make([]Mode, 0, len(list)), byKey: make(map[models.UserMode]Mode, len(list))}
+	styles := make(map[string]struct{}, len(list))
+	for i, mode := range list {
+		mode.Key = models.UserMode(strings.TrimSpace(string(mode.Key)))
+		mode.Style = strings.TrimSpace(mode.Style)
+		mode.Name = strings.TrimSpace(mode.Name)
+		mode.Button = strings.TrimSpace(mode.Button)
+		mode.Prompt = strings.TrimSpace(mode.Prompt)
+		if !keyPattern.MatchString(string(mode.Key)) {
+			return nil, fmt.Errorf("modes[%d]: key %q must be 1-32 of A-Z, 0-9, _", i, mode.Key)
+		}
+		if !stylePattern.MatchString(mode.Style) {
+			return nil, fmt.Errorf("modes[%d]: style %q must be 1-32 of a-z, 0-9, _", i, mode.Style)
+		}
+		if mode.Name == "" {
+			return nil, fmt.Errorf("modes[%d]: name is required", i)
+		}
+		if _, ok := Meta(mode.Key); ok {
+			return nil, fmt.Errorf("modes[%d]: key %q is reserved", i, mode.Key)
+		}
+		if _, ok := registry.byKey[mode.Key]; ok {
+			return nil, fmt.Errorf("modes[%d]: duplicate key %q", i, mode.Key)
+		}
+		if _, ok := styles[mode.Style]; ok {
+			return nil, fmt.Errorf("modes[%d]: duplicate style %q", i, mode.Style)
+		}
+		if mode.Button == "" {
+			mode.Button = mode.Name
+		}
+		emojis := make([]string, 0, len(mode.Emojis))
+		for _, emoji := range mode.Emojis {
+			if trimmed := strings.TrimSpace(emoji); trimmed != "" {
+				emojis = append(emojis, trimmed)
+			}
+		}
+		if len(emojis) == 0 {
+			emojis = constants.FallbackEmojis
+		}
+		mode.Emojis = emojis
+		styles[mode.Style] = struct{}{}
+		registry.byKey[mode.Key] = mode
+		registry.modes = append(registry.modes, mode)
+	}
+	return registry, nil
+}
+
+// Load reads a JSON array of modes; a missing file gives the built-in rofl/cozy/care.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return builtin(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read modes: %w", err)
+	}
+	var list []Mode
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("modes must be a JSON array: %w", err)
+	}
+	return NewRegistry(list)
+}
+
+// MustLoad loads path and makes it the current registry.
+func MustLoad(path string) {
+	registry, err := Load(path)
+	if err != nil {
+		stdlog.Fatalf("load modes: %v", err)
+	}
+	Set(registry)
+}
+
+// Set makes registry the one used by All, Get and Resolve.
+func Set(registry *Registry) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = registry
+}
+
+func registry() *Registry {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// All returns the modes in display order.
+func All() []Mode {
+	return append([]Mode(nil), registry().modes...)
+}
+
+func Get(key models.UserMode) (Mode, bool) {
+	mode, ok := registry().byKey[key]
+	return mode, ok
+}
+
+// Resolve returns the mode for key, falling back to the default mode for keys that are
+// no longer configured.
+func Resolve(key models.UserMode) Mode {
+	r := registry()
+	if mode, ok := r.byKey[key]; ok {
+		return mode
+	}
+	if mode, ok := r.byKey[constants.DefaultMode]; ok {
+		return mode
+	}
+	return r.modes[0]
+}
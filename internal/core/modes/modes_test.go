@@ -0,0 +1,74 @@
+package modes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"safeboxtgbot/internal/core/constants"
+)
+
+func TestLoad(t *testing.T) {
+	registry, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || len(registry.modes) != 3 {
+		t.Fatalf("expected the built-in modes for a missing file, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "modes.json")
+	content := `[{"key":"COZY_MODE","style":"cozy","name":"Уют"},{"key":"MOTIVATION_MODE","style":"motivation","name":"Мотивация","button":"💪 Мотивация","prompt":"бодро","emojis":["💪"," "]}]`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	registry, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cozy := registry.byKey[constants.CozyMode]
+	if cozy.Button != "Уют" || len(cozy.Emojis) != len(constants.FallbackEmojis) {
+		t.Fatalf("expected button and emoji defaults, got %+v", cozy)
+	}
+	motivation := registry.byKey["MOTIVATION_MODE"]
+	if motivation.Style != "motivation" || len(motivation.Emojis) != 1 {
+		t.Fatalf("unexpected custom mode %+v", motivation)
+	}
+
+	if _, err := Load(filepath.Join("..", "..", "..", "data", "modes.json")); err != nil {
+		t.Fatalf("shipped modes.json: %v", err)
+	}
+}
+
+func TestNewRegistryRejectsInvalid(t *testing.T) {
+	cases := [][]Mode{
+		nil,
+		{{Key: "cozy", Style: "cozy", Name: "Уют"}},
+		{{Key: "COZY_MODE", Style: "Cozy", Name: "Уют"}},
+		{{Key: "COZY_MODE", Style: "cozy"}},
+		{{Key: "COZY_MODE", Style: "cozy", Name: "Уют"}, {Key: "COZY_MODE", Style: "care", Name: "Забота"}},
+		{{Key: "COZY_MODE", Style: "cozy", Name: "Уют"}, {Key: "CARE_MODE", Style: "cozy", Name: "Забота"}},
+	}
+	for i, list := range cases {
+		if _, err := NewRegistry(list); err == nil {
+			t.Errorf("case %d: expected an error", i)
+		}
+	}
+}
+
+func TestResolveFallsBackToDefault(t *testing.T) {
+	custom, err := NewRegistry([]Mode{
+		{Key: "ROFL_MODE", Style: "rofl", Name: "Рофл"},
+		{Key: constants.DefaultMode, Style: "cozy", Name: "Уют"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := registry()
+	Set(custom)
+	defer Set(previous)
+
+	if got := Resolve("REMOVED_MODE"); got.Key != constants.DefaultMode {
+		t.Fatalf("expected the default mode, got %s", got.Key)
+	}
+	if _, ok := Get("REMOVED_MODE"); ok {
+		t.Fatal("expected an unknown key to be rejected")
+	}
+}
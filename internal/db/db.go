@@ -38,20 +38,55 @@ func MustDB(config *config.AppConfig, logger logger.AppLogger) *gorm.DB {
 		log.Fatal("Failed to AutoMigrate User: " + err.Error())
 	}
 
+	err = db.AutoMigrate(&models.Tag{})
+	if err != nil {
+		log.Fatal("Failed to AutoMigrate Tag: " + err.Error())
+	}
+
+	err = db.AutoMigrate(&models.SharedBox{})
+	if err != nil {
+		log.Fatal("Failed to AutoMigrate SharedBox: " + err.Error())
+	}
+
+	err = db.AutoMigrate(&models.SharedBoxMember{})
+	if err != nil {
+		log.Fatal("Failed to AutoMigrate SharedBoxMember: " + err.Error())
+	}
+
 	err = db.AutoMigrate(&models.Item{})
 	if err != nil {
 		log.Fatal("Failed to AutoMigrate Item: " + err.Error())
 	}
 
+	err = db.AutoMigrate(&models.ItemPhrase{})
+	if err != nil {
+		log.Fatal("Failed to AutoMigrate ItemPhrase: " + err.Error())
+	}
+
 	err = db.AutoMigrate(&models.MessageLog{})
 	if err != nil {
 		log.Fatal("Failed to AutoMigrate MessageLog: " + err.Error())
 	}
 
+	err = db.AutoMigrate(&models.PooledMessage{})
+	if err != nil {
+		log.Fatal("Failed to AutoMigrate PooledMessage: " + err.Error())
+	}
+
+	err = db.AutoMigrate(&models.LLMCall{})
+	if err != nil {
+		log.Fatal("Failed to AutoMigrate LLMCall: " + err.Error())
+	}
+
 	err = db.AutoMigrate(&models.Reminder{})
 	if err != nil {
 		log.Fatal("Failed to AutoMigrate Reminder: " + err.Error())
 	}
 
+	err = db.AutoMigrate(&models.ExcludedDate{})
+	if err != nil {
+		log.Fatal("Failed to AutoMigrate ExcludedDate: " + err.Error())
+	}
+
 	return db
 }
@@ -0,0 +1,71 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/core/logger"
+	"safeboxtgbot/internal/feat/items"
+	"safeboxtgbot/internal/feat/reminder"
+	"time"
+)
+
+// Worker hard-deletes items and reminders once their undo window has passed, and excluded
+// dates once they are in the past.
+type Worker struct {
+	itemsService     *items.Service
+	reminderService  *reminder.Service
+	exclusionService *reminder.ExclusionService
+	logger           logger.AppLogger
+}
+
+func NewWorker(itemsService *items.Service, reminderService *reminder.Service, exclusionService *reminder.ExclusionService, logger logger.AppLogger) *Worker {
+	return &Worker{itemsService: itemsService, reminderService: reminderService, exclusionService: exclusionService, logger: logger}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	ticker := time.NewTicker(constants.DeletionPurgeInterval)
+	defer ticker.Stop()
+
+	if ctx.Err() == nil {
+		w.processSafe()
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			w.processSafe()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *Worker) processSafe() {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			w.logger.Error(fmt.Sprintf("Cleanup worker panic: %v", recovered))
+		}
+	}()
+
+	w.process()
+}
+
+func (w *Worker) process() {
+	now := time.Now().UTC()
+	if purged, err := w.itemsService.PurgeDeleted(now); err != nil {
+		w.logger.Error(fmt.Sprintf("Error purging deleted items: %v", err))
+	} else if purged > 0 {
+		w.logger.Debug(fmt.Sprintf("Purged %d deleted items", purged))
+	}
+	if purged, err := w.reminderService.PurgeDeleted(now); err != nil {
+		w.logger.Error(fmt.Sprintf("Error purging deleted reminders: %v", err))
+	} else if purged > 0 {
+		w.logger.Debug(fmt.Sprintf("Purged %d deleted reminders", purged))
+	}
+	if purged, err := w.exclusionService.PurgePast(now); err != nil {
+		w.logger.Error(fmt.Sprintf("Error purging past excluded dates: %v", err))
+	} else if purged > 0 {
+		w.logger.Debug(fmt.Sprintf("Purged %d past excluded dates", purged))
+	}
+}
@@ -0,0 +1,104 @@
+package holiday
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed data/*.json
+var dataFS embed.FS
+
+// Calendar is an offline public-holiday dataset for one country.
+// Annual holds "MM-DD" days repeated every year, Dates holds one-off "YYYY-MM-DD" days off.
+// The bundled RU calendar only has the fixed annual holidays; days moved by the yearly
+// government decree are not included, so Dates is empty.
+type Calendar struct {
+	Code   string   `json:"code"`
+	Name   string   `json:"name"`
+	Annual []string `json:"annual"`
+	Dates  []string `json:"dates"`
+
+	annual map[string]struct{}
+	dates  map[string]struct{}
+}
+
+var calendars = mustLoadCalendars()
+
+// Lookup returns the bundled calendar by its country code (case-insensitive).
+func Lookup(code string) (*Calendar, bool) {
+	cal, ok := calendars[strings.ToUpper(strings.TrimSpace(code))]
+	return cal, ok
+}
+
+// Codes lists bundled calendar codes in alphabetical order.
+func Codes() []string {
+	codes := make([]string, 0, len(calendars))
+	for code := range calendars {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// IsHoliday reports whether the calendar date of day (in its own location) is a public holiday.
+func (c *Calendar) IsHoliday(day time.Time) bool {
+	if c == nil {
+		return false
+	}
+	if _, ok := c.annual[day.Format("01-02")]; ok {
+		return true
+	}
+	_, ok := c.dates[day.Format(time.DateOnly)]
+	return ok
+}
+
+func mustLoadCalendars() map[string]*Calendar {
+	entries, err := dataFS.ReadDir("data")
+	if err != nil {
+		panic(fmt.Sprintf("read holiday data: %v", err))
+	}
+	result := make(map[string]*Calendar, len(entries))
+	for _, entry := range entries {
+		raw, err := dataFS.ReadFile(path.Join("data", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("read holiday file %s: %v", entry.Name(), err))
+		}
+		cal, err := parseCalendar(raw)
+		if err != nil {
+			panic(fmt.Sprintf("parse holiday file %s: %v", entry.Name(), err))
+		}
+		result[cal.Code] = cal
+	}
+	return result
+}
+
+func parseCalendar(raw []byte) (*Calendar, error) {
+	var cal Calendar
+	if err := json.Unmarshal(raw, &cal); err != nil {
+		return nil, err
+	}
+	cal.Code = strings.ToUpper(strings.TrimSpace(cal.Code))
+	if cal.Code == "" {
+		return nil, fmt.Errorf("calendar code is empty")
+	}
+	cal.annual = make(map[string]struct{}, len(cal.Annual))
+	for _, day := range cal.Annual {
+		if _, err := time.Parse("01-02", day); err != nil {
+			return nil, fmt.Errorf("invalid annual day %q: %w", day, err)
+		}
+		cal.annual[day] = struct{}{}
+	}
+	cal.dates = make(map[string]struct{}, len(cal.Dates))
+	for _, day := range cal.Dates {
+		if _, err := time.Parse(time.DateOnly, day); err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", day, err)
+		}
+		cal.dates[day] = struct{}{}
+	}
+	return &cal, nil
+}
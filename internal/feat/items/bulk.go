@@ -0,0 +1,188 @@
+package items
+
+import (
+	"errors"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/models"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type ItemAddStatus int
+
+const (
+	ItemAdded ItemAddStatus = iota
+	ItemAddDuplicate
+	ItemAddTooLong
+	ItemAddLimitReached
+)
+
+// ItemAddResult is the outcome for one line of a bulk add.
+type ItemAddResult struct {
+	Input  string
+	Name   string
+	Status ItemAddStatus
+}
+
+// SplitItemNames splits a message into item names by newlines, commas and semicolons.
+func SplitItemNames(raw string) []string {
+	parts := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == '\n' || r == ',' || r == ';'
+	})
+	names := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			names = append(names, trimmed)
+		}
+	}
+	return names
+}
+
+// CreateItems adds every name from a multi-line message in one transaction.
+// On a DB error nothing is stored and the error is returned instead of a report.
+func (s *Service) CreateItems(userID int64, raw string) ([]ItemAddResult, error) {
+	if err := s.ensureItemsSessionLoaded(userID); err != nil {
+		return nil, err
+	}
+	inputs := SplitItemNames(raw)
+	if len(inputs) == 0 {
+		return nil, ErrItemNameEmpty
+	}
+
+	results := s.planItemAdds(s.store.GetItemList(userID), inputs)
+	toCreate := itemsToCreate(userID, results)
+	if len(toCreate) == 0 {
+		return results, nil
+	}
+
+	if err := s.itemRepo.CreateBatch(toCreate); err != nil {
+		return nil, err
+	}
+	return results, s.refreshItems(userID)
+}
+
+// ImportItems merges items into the box, or replaces the whole box when replace is set.
+// The same validation as for bulk add applies to names; notes and pauses are restored from
+// the file. A DB error leaves the box untouched.
+func (s *Service) ImportItems(userID int64, imported []ItemExport, replace bool) ([]ItemAddResult, error) {
+	if err := s.ensureItemsSessionLoaded(userID); err != nil {
+		return nil, err
+	}
+	existing := s.store.GetItemList(userID)
+	if replace {
+		existing = nil
+	}
+	names := make([]string, 0, len(imported))
+	byInput := make(map[string]ItemExport, len(imported))
+	for _, item := range imported {
+		names = append(names, item.Name)
+		if _, ok := byInput[item.Name]; !ok {
+			byInput[item.Name] = item
+		}
+	}
+	results := s.planItemAdds(existing, names)
+	toCreate := itemsToCreate(userID, results)
+	now := time.Now()
+	created := 0
+	for _, res := range results {
+		if res.Status == ItemAdded {
+			restoreImportedFields(&toCreate[created], byInput[res.Input], now)
+			created++
+		}
+	}
+
+	var err error
+	switch {
+	case replace:
+		err = s.itemRepo.ReplaceAll(userID, toCreate)
+	case len(toCreate) > 0:
+		err = s.itemRepo.CreateBatch(toCreate)
+	default:
+		return results, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, s.refreshItems(userID)
+}
+
+func (s *Service) SetPendingImport(userID int64, imported []ItemExport) {
+	pending := make([]models.Item, 0, len(imported))
+	for _, item := range imported {
+		pending = append(pending, models.Item{Name: item.Name, Note: item.Note, Paused: item.Paused, PausedUntil: item.PausedUntil})
+	}
+	s.store.SetPendingItemImport(userID, pending)
+}
+
+func (s *Service) GetPendingImport(userID int64) []ItemExport {
+	pending := s.store.GetPendingItemImport(userID)
+	imported := make([]ItemExport, 0, len(pending))
+	for _, item := range pending {
+		imported = append(imported, ItemExport{Name: item.Name, Note: item.Note, Paused: item.Paused, PausedUntil: item.PausedUntil})
+	}
+	return imported
+}
+
+func (s *Service) ClearPendingImport(userID int64) {
+	s.store.ClearPendingItemImport(userID)
+}
+
+func itemsToCreate(userID int64, results []ItemAddResult) []models.Item {
+	toCreate := make([]models.Item, 0, len(results))
+	for _, res := range results {
+		if res.Status == ItemAdded {
+			toCreate = append(toCreate, models.Item{UserID: userID, Name: res.Name})
+		}
+	}
+	return toCreate
+}
+
+// restoreImportedFields copies the note and pause of an imported item. Notes are normalized
+// like UpdateItemNote and dropped when too long; pauses that already ended are not restored.
+func restoreImportedFields(item *models.Item, imported ItemExport, now time.Time) {
+	if note := strings.Join(strings.Fields(imported.Note), " "); utf8.RuneCountInString(note) <= constants.MaxItemNoteLen {
+		item.Note = note
+	}
+	if imported.Paused && (imported.PausedUntil == nil || now.Before(*imported.PausedUntil)) {
+		item.Paused = true
+		if imported.PausedUntil != nil {
+			until := imported.PausedUntil.UTC()
+			item.PausedUntil = &until
+		}
+	}
+}
+
+func (s *Service) planItemAdds(existing []models.Item, inputs []string) []ItemAddResult {
+	seen := make(map[string]struct{}, len(existing)+len(inputs))
+	for _, item := range existing {
+		seen[item.Name] = struct{}{}
+	}
+	count := len(existing)
+
+	results := make([]ItemAddResult, 0, len(inputs))
+	for _, input := range inputs {
+		res := ItemAddResult{Input: input}
+		name, err := s.normalizeItemName(input)
+		if errors.Is(err, ErrItemNameEmpty) {
+			continue
+		}
+		if err != nil {
+			res.Status = ItemAddTooLong
+			results = append(results, res)
+			continue
+		}
+		res.Name = name
+		if _, dup := seen[name]; dup {
+			res.Status = ItemAddDuplicate
+		} else if count >= constants.MaxItemsPerUser {
+			res.Status = ItemAddLimitReached
+		} else {
+			seen[name] = struct{}{}
+			count++
+			res.Status = ItemAdded
+		}
+		results = append(results, res)
+	}
+	return results
+}
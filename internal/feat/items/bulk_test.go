@@ -0,0 +1,44 @@
+package items
+
+import (
+	"reflect"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/models"
+	"strings"
+	"testing"
+)
+
+func TestSplitItemNames(t *testing.T) {
+	got := SplitItemNames("чай\n кофе , ;какао;\n\n  ")
+	want := []string{"чай", "кофе", "какао"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("SplitItemNames = %q, want %q", got, want)
+	}
+}
+
+func TestPlanItemAdds(t *testing.T) {
+	s := &Service{}
+	existing := []models.Item{{Name: "чай"}}
+	inputs := []string{"Чай", "Кофе", "кофе", strings.Repeat("я", constants.MaxItemNameLen+1)}
+
+	got := s.planItemAdds(existing, inputs)
+	want := []ItemAddStatus{ItemAddDuplicate, ItemAdded, ItemAddDuplicate, ItemAddTooLong}
+	if len(got) != len(want) {
+		t.Fatalf("results = %d, want %d", len(got), len(want))
+	}
+	for i, status := range want {
+		if got[i].Status != status {
+			t.Fatalf("result %d (%q) status = %d, want %d", i, got[i].Input, got[i].Status, status)
+		}
+	}
+}
+
+func TestPlanItemAddsLimit(t *testing.T) {
+	s := &Service{}
+	existing := make([]models.Item, constants.MaxItemsPerUser-1)
+
+	got := s.planItemAdds(existing, []string{"a", "b"})
+	if got[0].Status != ItemAdded || got[1].Status != ItemAddLimitReached {
+		t.Fatalf("statuses = %d, %d, want added then limit reached", got[0].Status, got[1].Status)
+	}
+}
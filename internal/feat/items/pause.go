@@ -0,0 +1,65 @@
+package items
+
+import (
+	"safeboxtgbot/models"
+	"time"
+)
+
+// IsPaused reports whether the item is out of rotation at now.
+// A pause with a past PausedUntil has expired and counts as active.
+func IsPaused(item models.Item, now time.Time) bool {
+	if !item.Paused {
+		return false
+	}
+	return item.PausedUntil == nil || now.Before(*item.PausedUntil)
+}
+
+// ActiveItems drops paused items.
+func ActiveItems(list []models.Item, now time.Time) []models.Item {
+	active := make([]models.Item, 0, len(list))
+	for _, item := range list {
+		if !IsPaused(item, now) {
+			active = append(active, item)
+		}
+	}
+	return active
+}
+
+// Pause takes the item out of rotation; a nil until pauses it indefinitely.
+func (s *Service) Pause(userID int64, itemID uint, until *time.Time) error {
+	if until != nil {
+		utc := until.UTC()
+		until = &utc
+	}
+	return s.setPause(userID, itemID, true, until)
+}
+
+func (s *Service) Resume(userID int64, itemID uint) error {
+	return s.setPause(userID, itemID, false, nil)
+}
+
+func (s *Service) GetItemByID(userID int64, itemID uint) (*models.Item, error) {
+	if err := s.ensureItemsSessionLoaded(userID); err != nil {
+		return nil, err
+	}
+	for _, item := range s.store.GetItemList(userID) {
+		if item.ID == itemID {
+			return &item, nil
+		}
+	}
+	return nil, ErrItemNotFound
+}
+
+func (s *Service) setPause(userID int64, itemID uint, paused bool, until *time.Time) error {
+	if _, err := s.GetItemByID(userID, itemID); err != nil {
+		return err
+	}
+	updated, err := s.itemRepo.UpdatePause(userID, itemID, paused, until)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrItemNotFound
+	}
+	return s.refreshItems(userID)
+}
@@ -0,0 +1,39 @@
+package items
+
+import (
+	"safeboxtgbot/models"
+	"testing"
+	"time"
+)
+
+func TestActiveItemsSkipsPaused(t *testing.T) {
+	now := time.Date(2025, time.January, 10, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	list := []models.Item{
+		{Name: "active"},
+		{Name: "forever", Paused: true},
+		{Name: "until later", Paused: true, PausedUntil: &future},
+		{Name: "expired", Paused: true, PausedUntil: &past},
+	}
+
+	active := ActiveItems(list, now)
+	if len(active) != 2 || active[0].Name != "active" || active[1].Name != "expired" {
+		t.Fatalf("unexpected active items: %+v", active)
+	}
+}
+
+func TestIsPaused(t *testing.T) {
+	now := time.Date(2025, time.January, 10, 12, 0, 0, 0, time.UTC)
+	until := now.Add(time.Minute)
+
+	if IsPaused(models.Item{}, now) {
+		t.Fatal("item without a pause must be active")
+	}
+	if !IsPaused(models.Item{Paused: true, PausedUntil: &until}, now) {
+		t.Fatal("pause must hold before PausedUntil")
+	}
+	if IsPaused(models.Item{Paused: true, PausedUntil: &until}, until) {
+		t.Fatal("pause must end at PausedUntil")
+	}
+}
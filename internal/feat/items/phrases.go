@@ -0,0 +1,133 @@
+package items
+
+import (
+	"errors"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/models"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrPhraseEmpty        = errors.New("phrase empty")
+	ErrPhraseTooLong      = errors.New("phrase too long")
+	ErrPhraseDuplicate    = errors.New("phrase duplicate")
+	ErrPhraseLimitReached = errors.New("phrase limit reached")
+	ErrPhraseNotFound     = errors.New("phrase not found")
+	ErrPhraseShareInvalid = errors.New("phrase share invalid")
+)
+
+// PhraseShareSteps are the percentages offered in the UI for mixing phrases with LLM output.
+var PhraseShareSteps = []int16{0, 25, 50, 75, 100}
+
+// PickPhrase chooses a random unused phrase. When every phrase was already used, the cycle
+// restarts over the whole pool and resetCycle reports that the Used flags must be cleared.
+func PickPhrase(phrases []models.ItemPhrase, randIndex func(int) int) (picked models.ItemPhrase, resetCycle bool, ok bool) {
+	if len(phrases) == 0 {
+		return models.ItemPhrase{}, false, false
+	}
+	unused := make([]models.ItemPhrase, 0, len(phrases))
+	for _, phrase := range phrases {
+		if !phrase.Used {
+			unused = append(unused, phrase)
+		}
+	}
+	if len(unused) == 0 {
+		return phrases[randIndex(len(phrases))], true, true
+	}
+	return unused[randIndex(len(unused))], false, true
+}
+
+// Phrases lists the user's phrases for a personal item. Phrases are read from the DB on every
+// call: the worker flips their Used flags, so a session copy would go stale.
+func (s *Service) Phrases(userID int64, itemID uint) ([]models.ItemPhrase, error) {
+	if _, err := s.GetItemByID(userID, itemID); err != nil {
+		return nil, err
+	}
+	return s.phraseRepo.GetByItem(userID, itemID)
+}
+
+func (s *Service) AddPhrase(userID int64, itemID uint, rawText string) error {
+	phrases, err := s.Phrases(userID, itemID)
+	if err != nil {
+		return err
+	}
+	text := strings.Join(strings.Fields(rawText), " ")
+	if text == "" {
+		return ErrPhraseEmpty
+	}
+	if utf8.RuneCountInString(text) > constants.MaxPhraseLen {
+		return ErrPhraseTooLong
+	}
+	if len(phrases) >= constants.MaxPhrasesPerItem {
+		return ErrPhraseLimitReached
+	}
+	for _, phrase := range phrases {
+		if strings.EqualFold(phrase.Text, text) {
+			return ErrPhraseDuplicate
+		}
+	}
+	return s.phraseRepo.Create(&models.ItemPhrase{UserID: userID, ItemID: itemID, Text: text})
+}
+
+func (s *Service) DeletePhrase(userID int64, itemID uint, phraseID uint) error {
+	if _, err := s.GetItemByID(userID, itemID); err != nil {
+		return err
+	}
+	deleted, err := s.phraseRepo.Delete(userID, itemID, phraseID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrPhraseNotFound
+	}
+	return nil
+}
+
+// SetPhraseShare sets the percentage of nudges for the item that use a phrase instead of the LLM.
+func (s *Service) SetPhraseShare(userID int64, itemID uint, share int16) error {
+	valid := false
+	for _, step := range PhraseShareSteps {
+		if step == share {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return ErrPhraseShareInvalid
+	}
+	if _, err := s.GetItemByID(userID, itemID); err != nil {
+		return err
+	}
+	updated, err := s.itemRepo.UpdatePhraseShare(userID, itemID, share)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrItemNotFound
+	}
+	return s.refreshItems(userID)
+}
+
+// NextPhrase picks the next phrase of the item's pool for a nudge and marks it used.
+// It is called by the notification worker, so it reads the owner's phrases directly
+// instead of going through the user's item session; ok is false for an empty pool.
+func (s *Service) NextPhrase(item models.Item, randIndex func(int) int) (string, bool, error) {
+	phrases, err := s.phraseRepo.GetByItem(item.UserID, item.ID)
+	if err != nil {
+		return "", false, err
+	}
+	picked, resetCycle, ok := PickPhrase(phrases, randIndex)
+	if !ok {
+		return "", false, nil
+	}
+	if resetCycle {
+		if err := s.phraseRepo.ResetCycle(item.UserID, item.ID); err != nil {
+			return "", false, err
+		}
+	}
+	if err := s.phraseRepo.MarkUsed(picked.ID); err != nil {
+		return "", false, err
+	}
+	return picked.Text, true, nil
+}
@@ -0,0 +1,32 @@
+package items
+
+import (
+	"safeboxtgbot/models"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestPickPhrase(t *testing.T) {
+	first := func(int) int { return 0 }
+
+	if _, _, ok := PickPhrase(nil, first); ok {
+		t.Fatal("expected no phrase from an empty pool")
+	}
+
+	phrases := []models.ItemPhrase{
+		{Model: gorm.Model{ID: 1}, Text: "a", Used: true},
+		{Model: gorm.Model{ID: 2}, Text: "b"},
+		{Model: gorm.Model{ID: 3}, Text: "c", Used: true},
+	}
+	picked, reset, ok := PickPhrase(phrases, first)
+	if !ok || reset || picked.ID != 2 {
+		t.Fatalf("expected the only unused phrase, got id=%d reset=%t ok=%t", picked.ID, reset, ok)
+	}
+
+	phrases[1].Used = true
+	picked, reset, ok = PickPhrase(phrases, func(n int) int { return n - 1 })
+	if !ok || !reset || picked.ID != 3 {
+		t.Fatalf("expected a cycle reset over the full pool, got id=%d reset=%t ok=%t", picked.ID, reset, ok)
+	}
+}
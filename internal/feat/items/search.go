@@ -0,0 +1,23 @@
+package items
+
+import (
+	"safeboxtgbot/models"
+	"sort"
+	"strings"
+)
+
+// FilterSorted returns a name-sorted copy of list narrowed to names containing query.
+// Names are stored lowercased, so the query is matched case-insensitively.
+func FilterSorted(list []models.Item, query string) []models.Item {
+	query = strings.ToLower(strings.TrimSpace(query))
+	filtered := make([]models.Item, 0, len(list))
+	for _, item := range list {
+		if query == "" || strings.Contains(item.Name, query) {
+			filtered = append(filtered, item)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+	return filtered
+}
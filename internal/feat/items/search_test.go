@@ -0,0 +1,23 @@
+package items
+
+import (
+	"safeboxtgbot/models"
+	"testing"
+)
+
+func TestFilterSorted(t *testing.T) {
+	list := []models.Item{{Name: "чай"}, {Name: "кофе"}, {Name: "чайник"}, {Name: "какао"}}
+
+	all := FilterSorted(list, "")
+	if len(all) != 4 || all[0].Name != "какао" || all[3].Name != "чайник" {
+		t.Fatalf("unexpected sort order: %+v", all)
+	}
+
+	found := FilterSorted(list, "  ЧАЙ ")
+	if len(found) != 2 || found[0].Name != "чай" || found[1].Name != "чайник" {
+		t.Fatalf("unexpected search result: %+v", found)
+	}
+	if list[0].Name != "чай" {
+		t.Fatalf("FilterSorted must not reorder the input slice")
+	}
+}
@@ -21,23 +21,30 @@ var (
 	ErrItemDuplicate    = errors.New("item duplicate")
 	ErrItemLimitReached = errors.New("item limit reached")
 	ErrItemNotFound     = errors.New("item not found")
+	ErrItemNoteTooLong  = errors.New("item note too long")
 )
 
 type Service struct {
-	store    *session.Store
-	itemRepo *repo.ItemRepo
-	logger   logger.AppLogger
+	store      *session.Store
+	itemRepo   *repo.ItemRepo
+	boxRepo    *repo.SharedBoxRepo
+	phraseRepo *repo.PhraseRepo
+	logger     logger.AppLogger
 }
 
 func NewService(
 	itemRepo *repo.ItemRepo,
+	boxRepo *repo.SharedBoxRepo,
+	phraseRepo *repo.PhraseRepo,
 	store *session.Store,
 	logger logger.AppLogger,
 ) *Service {
 	return &Service{
-		store:    store,
-		itemRepo: itemRepo,
-		logger:   logger,
+		store:      store,
+		itemRepo:   itemRepo,
+		boxRepo:    boxRepo,
+		phraseRepo: phraseRepo,
+		logger:     logger,
 	}
 }
 
@@ -113,6 +120,26 @@ func (s *Service) UpdateItemName(userID int64, itemID uint, rawName string) erro
 	return s.refreshItems(userID)
 }
 
+// UpdateItemNote sets the LLM context note of an item; an empty note clears it.
+func (s *Service) UpdateItemNote(userID int64, itemID uint, rawNote string) error {
+	if _, err := s.GetItemByID(userID, itemID); err != nil {
+		return err
+	}
+	note := strings.Join(strings.Fields(rawNote), " ")
+	if utf8.RuneCountInString(note) > constants.MaxItemNoteLen {
+		return ErrItemNoteTooLong
+	}
+
+	updated, err := s.itemRepo.UpdateNote(userID, itemID, note)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrItemNotFound
+	}
+	return s.refreshItems(userID)
+}
+
 func (s *Service) DeleteItem(userID int64, itemID uint) error {
 	if err := s.ensureItemsSessionLoaded(userID); err != nil {
 		return err
@@ -160,6 +187,57 @@ func (s *Service) ClearEditingItemID(userID int64) {
 	s.store.ClearEditingItemID(userID)
 }
 
+func (s *Service) GetPage(userID int64) int {
+	return s.store.GetItemsPage(userID)
+}
+
+func (s *Service) SetPage(userID int64, page int) {
+	s.store.SetItemsPage(userID, page)
+}
+
+func (s *Service) GetQuery(userID int64) string {
+	return s.store.GetItemsQuery(userID)
+}
+
+// SetQuery stores the picker search filter and resets paging.
+func (s *Service) SetQuery(userID int64, query string) {
+	s.store.SetItemsQuery(userID, strings.ToLower(strings.Join(strings.Fields(query), " ")))
+}
+
+func (s *Service) ResetPaging(userID int64) {
+	s.store.ResetItemsPaging(userID)
+}
+
+// Search returns items whose name contains query, sorted by name.
+func (s *Service) Search(userID int64, query string) ([]models.Item, error) {
+	list, err := s.GetItemList(userID)
+	if err != nil {
+		return nil, err
+	}
+	return FilterSorted(list, query), nil
+}
+
+func (s *Service) GetSuggestions(userID int64) []string {
+	return s.store.GetItemSuggestions(userID)
+}
+
+func (s *Service) SetSuggestions(userID int64, suggestions []string) {
+	s.store.SetItemSuggestions(userID, suggestions)
+}
+
+// GetItemIDByName finds a personal item by its normalized name.
+func (s *Service) GetItemIDByName(userID int64, name string) (uint, error) {
+	if err := s.ensureItemsSessionLoaded(userID); err != nil {
+		return 0, err
+	}
+	for _, item := range s.store.GetItemList(userID) {
+		if item.Name == name {
+			return item.ID, nil
+		}
+	}
+	return 0, ErrItemNotFound
+}
+
 func (s *Service) GetItemNameByID(userID int64, itemID uint) (string, error) {
 	if err := s.ensureItemsSessionLoaded(userID); err != nil {
 		return "", err
@@ -190,6 +268,11 @@ func (s *Service) ensureItemsSessionLoaded(userID int64) error {
 	return nil
 }
 
+// Reload re-reads the item list after changes made outside this service, e.g. tag assignment.
+func (s *Service) Reload(userID int64) error {
+	return s.refreshItems(userID)
+}
+
 func (s *Service) refreshItems(userID int64) error {
 	items, err := s.itemRepo.GetByTelegramID(userID)
 	if err != nil {
@@ -0,0 +1,311 @@
+package items
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/models"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrBoxNotFound         = errors.New("shared box not found")
+	ErrBoxNameEmpty        = errors.New("shared box name empty")
+	ErrBoxNameTooLong      = errors.New("shared box name too long")
+	ErrBoxLimitReached     = errors.New("shared box limit reached")
+	ErrBoxFull             = errors.New("shared box member limit reached")
+	ErrBoxItemLimitReached = errors.New("shared box item limit reached")
+	ErrAlreadyBoxMember    = errors.New("already a shared box member")
+	ErrJoinCodeLocked      = errors.New("too many wrong join codes")
+	ErrNotBoxOwner         = errors.New("not the shared box owner")
+	ErrOwnerCannotLeave    = errors.New("shared box owner cannot leave")
+)
+
+// joinCodeAlphabet leaves out characters that are easy to confuse when typed: 0/O, 1/I/L.
+const joinCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// SharedBoxes lists the user's memberships with their boxes. Shared data is read from the DB
+// on every call: other members change it, so it is not cached in the session.
+func (s *Service) SharedBoxes(userID int64) ([]models.SharedBoxMember, error) {
+	return s.boxRepo.GetMembershipsByUser(userID)
+}
+
+// SharedBox returns the user's membership in the box; non-members get ErrBoxNotFound.
+func (s *Service) SharedBox(userID int64, boxID uint) (*models.SharedBoxMember, error) {
+	member, found, err := s.boxRepo.TryGetMembership(boxID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrBoxNotFound
+	}
+	return member, nil
+}
+
+func (s *Service) CreateSharedBox(userID int64, rawName string) (*models.SharedBox, error) {
+	name := strings.Join(strings.Fields(rawName), " ")
+	if name == "" {
+		return nil, ErrBoxNameEmpty
+	}
+	if utf8.RuneCountInString(name) > constants.MaxSharedBoxNameLen {
+		return nil, ErrBoxNameTooLong
+	}
+	if err := s.ensureBoxSlot(userID); err != nil {
+		return nil, err
+	}
+
+	code, err := s.uniqueJoinCode()
+	if err != nil {
+		return nil, err
+	}
+	box := &models.SharedBox{OwnerID: userID, Name: name, JoinCode: code}
+	if err := s.boxRepo.Create(box); err != nil {
+		return nil, err
+	}
+	return box, nil
+}
+
+// JoinSharedBox adds the user to the box with the given join code. After MaxJoinCodeAttempts
+// unknown codes further attempts fail with ErrJoinCodeLocked for JoinCodeLockout.
+func (s *Service) JoinSharedBox(userID int64, rawCode string) (*models.SharedBox, error) {
+	now := time.Now()
+	if now.Before(s.store.GetJoinCodeLockedUntil(userID)) {
+		return nil, ErrJoinCodeLocked
+	}
+	box, found, err := s.boxRepo.TryGetByJoinCode(NormalizeJoinCode(rawCode))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		s.store.RegisterJoinCodeFailure(userID, constants.MaxJoinCodeAttempts, constants.JoinCodeLockout, now)
+		return nil, ErrBoxNotFound
+	}
+	_, isMember, err := s.boxRepo.TryGetMembership(box.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		return nil, ErrAlreadyBoxMember
+	}
+	if err := s.ensureBoxSlot(userID); err != nil {
+		return nil, err
+	}
+	added, err := s.boxRepo.AddMemberCapped(&models.SharedBoxMember{BoxID: box.ID, UserID: userID}, constants.MaxSharedBoxMembers)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, ErrBoxFull
+	}
+	s.store.ResetJoinCodeFailures(userID)
+	return box, nil
+}
+
+// LeaveSharedBox drops the membership; the owner has to delete the box instead.
+func (s *Service) LeaveSharedBox(userID int64, boxID uint) error {
+	member, err := s.SharedBox(userID, boxID)
+	if err != nil {
+		return err
+	}
+	if member.Box.OwnerID == userID {
+		return ErrOwnerCannotLeave
+	}
+	if _, err := s.boxRepo.RemoveMember(boxID, userID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) KickSharedBoxMember(ownerID int64, boxID uint, memberID int64) error {
+	if _, err := s.requireBoxOwner(ownerID, boxID); err != nil {
+		return err
+	}
+	if memberID == ownerID {
+		return ErrOwnerCannotLeave
+	}
+	removed, err := s.boxRepo.RemoveMember(boxID, memberID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrBoxNotFound
+	}
+	return nil
+}
+
+func (s *Service) DeleteSharedBox(ownerID int64, boxID uint) error {
+	if _, err := s.requireBoxOwner(ownerID, boxID); err != nil {
+		return err
+	}
+	deleted, err := s.boxRepo.Delete(boxID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrBoxNotFound
+	}
+	return nil
+}
+
+// SetSharedBoxMuted is the per-member opt-out: a muted box is left out of the user's rotation.
+func (s *Service) SetSharedBoxMuted(userID int64, boxID uint, muted bool) error {
+	if _, err := s.SharedBox(userID, boxID); err != nil {
+		return err
+	}
+	updated, err := s.boxRepo.UpdateMemberMuted(boxID, userID, muted)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrBoxNotFound
+	}
+	return nil
+}
+
+func (s *Service) SharedBoxMembers(userID int64, boxID uint) ([]models.SharedBoxMember, error) {
+	if _, err := s.SharedBox(userID, boxID); err != nil {
+		return nil, err
+	}
+	return s.boxRepo.GetMembers(boxID)
+}
+
+func (s *Service) SharedBoxItems(userID int64, boxID uint) ([]models.Item, error) {
+	if _, err := s.SharedBox(userID, boxID); err != nil {
+		return nil, err
+	}
+	return s.boxRepo.GetItems(boxID)
+}
+
+func (s *Service) AddSharedBoxItem(userID int64, boxID uint, rawName string) error {
+	name, err := s.normalizeItemName(rawName)
+	if err != nil {
+		return err
+	}
+	existing, err := s.SharedBoxItems(userID, boxID)
+	if err != nil {
+		return err
+	}
+	if len(existing) >= constants.MaxSharedBoxItems {
+		return ErrBoxItemLimitReached
+	}
+	for _, item := range existing {
+		if item.Name == name {
+			return ErrItemDuplicate
+		}
+	}
+	return s.boxRepo.CreateItem(&models.Item{UserID: userID, BoxID: &boxID, Name: name})
+}
+
+// RemoveSharedBoxItem lets the owner remove any item and other members only the ones they added.
+func (s *Service) RemoveSharedBoxItem(userID int64, boxID uint, itemID uint) error {
+	member, err := s.SharedBox(userID, boxID)
+	if err != nil {
+		return err
+	}
+	list, err := s.boxRepo.GetItems(boxID)
+	if err != nil {
+		return err
+	}
+	for _, item := range list {
+		if item.ID != itemID {
+			continue
+		}
+		if item.UserID != userID && member.Box.OwnerID != userID {
+			return ErrNotBoxOwner
+		}
+		if _, err := s.boxRepo.DeleteItem(boxID, itemID); err != nil {
+			return err
+		}
+		return nil
+	}
+	return ErrItemNotFound
+}
+
+// RotationItems returns the user's personal items plus items of shared boxes they have not muted.
+func (s *Service) RotationItems(userID int64) ([]models.Item, error) {
+	own, err := s.GetItemList(userID)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := s.boxRepo.GetRotationItems(userID)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]models.Item, 0, len(own)+len(shared))
+	list = append(list, own...)
+	return append(list, shared...), nil
+}
+
+func (s *Service) GetOpenSharedBoxID(userID int64) uint {
+	return s.store.GetSharedBoxID(userID)
+}
+
+func (s *Service) SetOpenSharedBoxID(userID int64, boxID uint) {
+	s.store.SetSharedBoxID(userID, boxID)
+}
+
+// NormalizeJoinCode uppercases the code and drops spaces and dashes people add when retyping it.
+func NormalizeJoinCode(raw string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '\t' || r == '\n' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(raw))
+}
+
+// NewJoinCode returns a random code of SharedBoxJoinCodeLen characters from joinCodeAlphabet.
+func NewJoinCode() (string, error) {
+	code := make([]byte, constants.SharedBoxJoinCodeLen)
+	limit := big.NewInt(int64(len(joinCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", err
+		}
+		code[i] = joinCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+func (s *Service) uniqueJoinCode() (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		code, err := NewJoinCode()
+		if err != nil {
+			return "", err
+		}
+		_, taken, err := s.boxRepo.TryGetByJoinCode(code)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return code, nil
+		}
+	}
+	return "", errors.New("could not generate a unique join code")
+}
+
+func (s *Service) ensureBoxSlot(userID int64) error {
+	boxes, err := s.boxRepo.GetMembershipsByUser(userID)
+	if err != nil {
+		return err
+	}
+	if len(boxes) >= constants.MaxSharedBoxesPerUser {
+		return ErrBoxLimitReached
+	}
+	return nil
+}
+
+func (s *Service) requireBoxOwner(userID int64, boxID uint) (*models.SharedBoxMember, error) {
+	member, err := s.SharedBox(userID, boxID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Box.OwnerID != userID {
+		return nil, ErrNotBoxOwner
+	}
+	return member, nil
+}
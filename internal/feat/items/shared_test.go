@@ -0,0 +1,35 @@
+package items
+
+import (
+	"safeboxtgbot/internal/core/constants"
+	"strings"
+	"testing"
+)
+
+func TestNewJoinCode(t *testing.T) {
+	seen := make(map[string]struct{})
+	for i := 0; i < 50; i++ {
+		code, err := NewJoinCode()
+		if err != nil {
+			t.Fatalf("NewJoinCode: %v", err)
+		}
+		if len(code) != constants.SharedBoxJoinCodeLen {
+			t.Fatalf("code %q has length %d", code, len(code))
+		}
+		for _, r := range code {
+			if !strings.ContainsRune(joinCodeAlphabet, r) {
+				t.Fatalf("code %q contains %q outside the alphabet", code, r)
+			}
+		}
+		seen[code] = struct{}{}
+	}
+	if len(seen) < 45 {
+		t.Fatalf("codes repeat too often: %d unique of 50", len(seen))
+	}
+}
+
+func TestNormalizeJoinCode(t *testing.T) {
+	if got := NormalizeJoinCode(" abcd-ef 23\n"); got != "ABCDEF23" {
+		t.Fatalf("NormalizeJoinCode = %q", got)
+	}
+}
@@ -0,0 +1,84 @@
+package items
+
+import (
+	"safeboxtgbot/internal/core/logger"
+	"safeboxtgbot/internal/repo"
+	"safeboxtgbot/models"
+	"time"
+)
+
+// ItemStats summarizes the nudges sent about one item.
+type ItemStats struct {
+	Count      int
+	LastSentAt time.Time     // zero when nothing was sent
+	AvgGap     time.Duration // mean time between consecutive nudges; zero with fewer than two
+}
+
+// ComputeStats summarizes logs of a single item; the order of logs does not matter.
+func ComputeStats(logs []models.MessageLog) ItemStats {
+	stats := ItemStats{Count: len(logs)}
+	if len(logs) == 0 {
+		return stats
+	}
+	first, last := logs[0].SentAt, logs[0].SentAt
+	for _, log := range logs[1:] {
+		if log.SentAt.Before(first) {
+			first = log.SentAt
+		}
+		if log.SentAt.After(last) {
+			last = log.SentAt
+		}
+	}
+	stats.LastSentAt = last
+	if len(logs) > 1 {
+		stats.AvgGap = last.Sub(first) / time.Duration(len(logs)-1)
+	}
+	return stats
+}
+
+// StatsFromAggregate turns the per-item counts aggregated in SQL into ItemStats.
+func StatsFromAggregate(rows []repo.ItemLogStats) map[uint]ItemStats {
+	stats := make(map[uint]ItemStats, len(rows))
+	for _, row := range rows {
+		item := ItemStats{Count: int(row.Count)}
+		if row.Count > 0 {
+			item.LastSentAt = time.Unix(row.LastSent, 0).UTC()
+		}
+		if row.Count > 1 {
+			item.AvgGap = time.Duration(row.LastSent-row.FirstSent) * time.Second / time.Duration(row.Count-1)
+		}
+		stats[row.ItemID] = item
+	}
+	return stats
+}
+
+// StatsService reads the notification log for the per-item statistics screen.
+type StatsService struct {
+	messageLogRepo *repo.MessageLogRepo
+	logger         logger.AppLogger
+}
+
+func NewStatsService(messageLogRepo *repo.MessageLogRepo, logger logger.AppLogger) *StatsService {
+	return &StatsService{messageLogRepo: messageLogRepo, logger: logger}
+}
+
+func (s *StatsService) ByItem(userID int64) (map[uint]ItemStats, error) {
+	rows, err := s.messageLogRepo.StatsByItem(userID)
+	if err != nil {
+		return nil, err
+	}
+	return StatsFromAggregate(rows), nil
+}
+
+// ForItem returns the item summary and up to limit of its latest logs, newest first.
+func (s *StatsService) ForItem(userID int64, itemID uint, limit int) (ItemStats, []models.MessageLog, error) {
+	logs, err := s.messageLogRepo.GetByUserAndItem(userID, itemID)
+	if err != nil {
+		return ItemStats{}, nil, err
+	}
+	recent := logs
+	if len(recent) > limit {
+		recent = recent[:limit]
+	}
+	return ComputeStats(logs), recent, nil
+}
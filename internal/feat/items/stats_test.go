@@ -0,0 +1,46 @@
+package items
+
+import (
+	"safeboxtgbot/internal/repo"
+	"safeboxtgbot/models"
+	"testing"
+	"time"
+)
+
+func TestComputeStats(t *testing.T) {
+	base := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	logs := []models.MessageLog{
+		{ItemID: 1, SentAt: base.Add(6 * time.Hour)},
+		{ItemID: 1, SentAt: base},
+		{ItemID: 1, SentAt: base.Add(2 * time.Hour)},
+	}
+
+	stats := ComputeStats(logs)
+	if stats.Count != 3 || !stats.LastSentAt.Equal(base.Add(6*time.Hour)) || stats.AvgGap != 3*time.Hour {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	single := ComputeStats(logs[:1])
+	if single.Count != 1 || single.AvgGap != 0 {
+		t.Fatalf("unexpected single stats: %+v", single)
+	}
+	if empty := ComputeStats(nil); empty.Count != 0 || !empty.LastSentAt.IsZero() {
+		t.Fatalf("unexpected empty stats: %+v", empty)
+	}
+}
+
+func TestStatsFromAggregate(t *testing.T) {
+	base := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	rows := []repo.ItemLogStats{
+		{ItemID: 1, Count: 3, FirstSent: base.Unix(), LastSent: base.Add(4 * time.Hour).Unix()},
+		{ItemID: 2, Count: 1, FirstSent: base.Unix(), LastSent: base.Unix()},
+	}
+
+	stats := StatsFromAggregate(rows)
+	if len(stats) != 2 || stats[1].Count != 3 || stats[1].AvgGap != 2*time.Hour || !stats[1].LastSentAt.Equal(base.Add(4*time.Hour)) {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if stats[2].Count != 1 || stats[2].AvgGap != 0 || !stats[2].LastSentAt.Equal(base) {
+		t.Fatalf("unexpected single stats: %+v", stats[2])
+	}
+}
@@ -0,0 +1,179 @@
+package items
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"safeboxtgbot/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrImportFormat = errors.New("unsupported item import format")
+
+type ExportFormat string
+
+const (
+	ExportFormatJSON ExportFormat = "json"
+	ExportFormatCSV  ExportFormat = "csv"
+)
+
+const itemsExportVersion = 1
+
+var itemsCSVHeader = []string{"name", "created_at", "paused", "paused_until", "note"}
+
+// ItemExport is one item in an export file.
+type ItemExport struct {
+	Name        string     `json:"name"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Note        string     `json:"note,omitempty"`
+	Paused      bool       `json:"paused,omitempty"`
+	PausedUntil *time.Time `json:"paused_until,omitempty"`
+}
+
+type itemsExportFile struct {
+	Version    int          `json:"version"`
+	ExportedAt time.Time    `json:"exported_at"`
+	Items      []ItemExport `json:"items"`
+}
+
+// Export renders the item list as a JSON or CSV document.
+func Export(items []models.Item, format ExportFormat, now time.Time) ([]byte, error) {
+	exported := make([]ItemExport, 0, len(items))
+	for _, item := range items {
+		exported = append(exported, ItemExport{
+			Name:        item.Name,
+			CreatedAt:   item.CreatedAt.UTC(),
+			Note:        item.Note,
+			Paused:      item.Paused,
+			PausedUntil: item.PausedUntil,
+		})
+	}
+
+	switch format {
+	case ExportFormatJSON:
+		return json.MarshalIndent(itemsExportFile{
+			Version:    itemsExportVersion,
+			ExportedAt: now.UTC(),
+			Items:      exported,
+		}, "", "  ")
+	case ExportFormatCSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write(itemsCSVHeader); err != nil {
+			return nil, err
+		}
+		for _, item := range exported {
+			pausedUntil := ""
+			if item.PausedUntil != nil {
+				pausedUntil = item.PausedUntil.UTC().Format(time.RFC3339)
+			}
+			record := []string{item.Name, item.CreatedAt.Format(time.RFC3339), strconv.FormatBool(item.Paused), pausedUntil, item.Note}
+			if err := w.Write(record); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	default:
+		return nil, ErrImportFormat
+	}
+}
+
+// ImportFormatByName picks the format from the file extension.
+func ImportFormatByName(fileName string) (ExportFormat, bool) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		return ExportFormatJSON, true
+	case ".csv":
+		return ExportFormatCSV, true
+	default:
+		return "", false
+	}
+}
+
+// ParseImport reads items from an export file. JSON accepts the export object, a bare array
+// of objects with "name", or a bare array of strings. CSV with the export header restores
+// the note and pause columns; without it only the first column is read as the name.
+func ParseImport(r io.Reader, format ExportFormat) ([]ItemExport, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	switch format {
+	case ExportFormatJSON:
+		return parseJSONImport(data)
+	case ExportFormatCSV:
+		return parseCSVImport(data)
+	default:
+		return nil, ErrImportFormat
+	}
+}
+
+func parseJSONImport(data []byte) ([]ItemExport, error) {
+	var file itemsExportFile
+	if err := json.Unmarshal(data, &file); err == nil && file.Items != nil {
+		return file.Items, nil
+	}
+	var objects []ItemExport
+	if err := json.Unmarshal(data, &objects); err == nil {
+		return objects, nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		items := make([]ItemExport, 0, len(names))
+		for _, name := range names {
+			items = append(items, ItemExport{Name: name})
+		}
+		return items, nil
+	}
+	return nil, ErrImportFormat
+}
+
+func parseCSVImport(data []byte) ([]ItemExport, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, ErrImportFormat
+	}
+	columns := map[string]int{itemsCSVHeader[0]: 0}
+	items := make([]ItemExport, 0, len(records))
+	for i, record := range records {
+		if len(record) == 0 {
+			continue
+		}
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), itemsCSVHeader[0]) {
+			for index, column := range record {
+				if key := strings.ToLower(strings.TrimSpace(column)); key != "" {
+					if _, seen := columns[key]; !seen {
+						columns[key] = index // the first of repeated columns wins
+					}
+				}
+			}
+			continue
+		}
+		field := func(name string) string {
+			if index, ok := columns[name]; ok && index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+		item := ItemExport{Name: field(itemsCSVHeader[0]), Note: field("note")}
+		if item.Name == "" {
+			continue
+		}
+		item.Paused, _ = strconv.ParseBool(field("paused"))
+		if until, err := time.Parse(time.RFC3339, field("paused_until")); err == nil {
+			item.PausedUntil = &until
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,86 @@
+package items
+
+import (
+	"bytes"
+	"reflect"
+	"safeboxtgbot/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportParseImportRoundTrip(t *testing.T) {
+	until := time.Date(2025, time.February, 1, 9, 0, 0, 0, time.UTC)
+	list := []models.Item{
+		{Name: "чай", Note: "зелёный, без сахара"},
+		{Name: "кофе, с молоком", Paused: true, PausedUntil: &until},
+		{Name: "сон", Paused: true},
+	}
+	want := []ItemExport{
+		{Name: "чай", Note: "зелёный, без сахара"},
+		{Name: "кофе, с молоком", Paused: true, PausedUntil: &until},
+		{Name: "сон", Paused: true},
+	}
+	now := time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, format := range []ExportFormat{ExportFormatJSON, ExportFormatCSV} {
+		data, err := Export(list, format, now)
+		if err != nil {
+			t.Fatalf("%s export: %v", format, err)
+		}
+		got, err := ParseImport(bytes.NewReader(data), format)
+		if err != nil {
+			t.Fatalf("%s parse: %v", format, err)
+		}
+		for i := range got {
+			got[i].CreatedAt = time.Time{}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s items = %+v, want %+v", format, got, want)
+		}
+	}
+}
+
+func TestParseImportBareJSONArrays(t *testing.T) {
+	want := []ItemExport{{Name: "чай"}, {Name: "кофе"}}
+	for _, raw := range []string{`["чай","кофе"]`, `[{"name":"чай"},{"name":"кофе"}]`} {
+		got, err := ParseImport(strings.NewReader(raw), ExportFormatJSON)
+		if err != nil {
+			t.Fatalf("parse %s: %v", raw, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("parse %s = %+v", raw, got)
+		}
+	}
+	if _, err := ParseImport(strings.NewReader(`{"foo":1}`), ExportFormatJSON); err == nil {
+		t.Fatalf("expected error for unknown JSON shape")
+	}
+}
+
+func TestParseImportCSVShortRows(t *testing.T) {
+	got, err := ParseImport(strings.NewReader("name,x,name\nfoo\n,,\nбар,,чай\n"), ExportFormatCSV)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if want := []ItemExport{{Name: "foo"}, {Name: "бар"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("items = %+v, want %+v", got, want)
+	}
+}
+
+func TestRestoreImportedFields(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	var item models.Item
+	restoreImportedFields(&item, ItemExport{Note: "  два   слова ", Paused: true, PausedUntil: &future}, now)
+	if item.Note != "два слова" || !item.Paused || item.PausedUntil == nil || !item.PausedUntil.Equal(future) {
+		t.Fatalf("note and active pause must be restored, got %+v", item)
+	}
+
+	item = models.Item{}
+	restoreImportedFields(&item, ItemExport{Note: strings.Repeat("я", 1000), Paused: true, PausedUntil: &past}, now)
+	if item.Note != "" || item.Paused || item.PausedUntil != nil {
+		t.Fatalf("too long note and expired pause must be dropped, got %+v", item)
+	}
+}
@@ -0,0 +1,48 @@
+package items
+
+import (
+	"errors"
+	"safeboxtgbot/internal/core/constants"
+	"time"
+)
+
+var ErrUndoExpired = errors.New("undo window expired")
+
+// RestoreItem brings back an item deleted less than constants.DeletionUndoWindow ago.
+// The row is un-deleted in place, so its ID, phrases and message history stay attached.
+func (s *Service) RestoreItem(userID int64, itemID uint, now time.Time) (string, error) {
+	if err := s.ensureItemsSessionLoaded(userID); err != nil {
+		return "", err
+	}
+	item, found, err := s.itemRepo.TryGetDeleted(userID, itemID, now.Add(-constants.DeletionUndoWindow))
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", ErrUndoExpired
+	}
+
+	items := s.store.GetItemList(userID)
+	if len(items) >= constants.MaxItemsPerUser {
+		return "", ErrItemLimitReached
+	}
+	for _, existing := range items {
+		if existing.Name == item.Name {
+			return "", ErrItemDuplicate
+		}
+	}
+
+	restored, err := s.itemRepo.Restore(userID, itemID)
+	if err != nil {
+		return "", err
+	}
+	if !restored {
+		return "", ErrUndoExpired
+	}
+	return item.Name, s.refreshItems(userID)
+}
+
+// PurgeDeleted permanently removes items whose undo window closed before now.
+func (s *Service) PurgeDeleted(now time.Time) (int64, error) {
+	return s.itemRepo.PurgeDeleted(now.Add(-constants.DeletionUndoWindow))
+}
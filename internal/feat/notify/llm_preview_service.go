@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	b "safeboxtgbot/internal"
-	"safeboxtgbot/internal/core/constants"
 	"safeboxtgbot/internal/core/logger"
 	"safeboxtgbot/internal/feat/items"
 	"safeboxtgbot/internal/feat/prompt"
@@ -90,17 +89,18 @@ func (s *LLMPreviewService) SendPreviews(ctx context.Context, userID int64) erro
 	loc := previewUserLocation(s.logger, *userDTO)
 	localNow := time.Now().In(loc)
 	timeOfDayValue := helpers.TimeOfDay(localNow)
-	style := helpers.ModeToStyle(userDTO.Mode)
-
 	for _, item := range itemList {
+		mode := helpers.ModeAt(*userDTO, localNow)
 		input := prompt.LLMInput{
 			CurrentEntity: item.Name,
+			EntityNote:    item.Note,
 			TimeOfDay:     timeOfDayValue,
-			StyleMode:     style,
+			StyleMode:     helpers.ModeToStyle(mode),
+			StylePrompt:   helpers.ModeStylePrompt(mode),
 			RandomSeed:    utils.RandomIntRange(1, 1_000_000),
 		}
 
-		genCtx, cancel := context.WithTimeout(ctx, 5000*time.Second)
+		genCtx, cancel := context.WithTimeout(prompt.WithUserID(ctx, userDTO.TelegramID), 5000*time.Second)
 		var (
 			text   string
 			genErr error
@@ -136,7 +136,7 @@ func (s *LLMPreviewService) SendPreviews(ctx context.Context, userID int64) erro
 			if s.logger != nil {
 				s.logger.Error(fmt.Sprintf("LLM preview generation failed for userID=%d item=%q: %v", userDTO.TelegramID, item.Name, genErr))
 			}
-			text = helpers.FallbackText(item.Name, constants.FallbackEmojis)
+			text = helpers.FallbackText(item.Name, helpers.ModeFallbackEmojis(mode))
 		}
 
 		payload := fmt.Sprintf("%s: %s", item.Name, text)
@@ -164,8 +164,12 @@ func (s *LLMPreviewService) generateFallback(ctx context.Context, input prompt.L
 	if s.fallback == nil || s.builder == nil {
 		return "", errors.New("no fallback available")
 	}
+	systemPrompt, err := s.builder.BuildSystem(input)
+	if err != nil {
+		return "", err
+	}
 	req := prompt.LLMRequest{
-		SystemPrompt: s.builder.BuildSystem(),
+		SystemPrompt: systemPrompt,
 		UserPrompt:   s.builder.BuildUser(input),
 		Temperature:  0.8,
 		MaxTokens:    180,
@@ -0,0 +1,256 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/core/logger"
+	"safeboxtgbot/internal/core/modes"
+	"safeboxtgbot/internal/feat/items"
+	"safeboxtgbot/internal/feat/prompt"
+	"safeboxtgbot/internal/feat/user"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/internal/repo"
+	"safeboxtgbot/models"
+)
+
+// MessagePool keeps a few LLM texts ready per (item, mode, time of day) so that a nudge
+// does not wait for the LLM. Texts are generated by PoolWorker in the background.
+type MessagePool struct {
+	repo   *repo.MessagePoolRepo
+	refill chan struct{}
+}
+
+func NewMessagePool(repo *repo.MessagePoolRepo) *MessagePool {
+	return &MessagePool{
+		repo:   repo,
+		refill: make(chan struct{}, 1),
+	}
+}
+
+// Take pops a ready text in one of the modes for the item as it is named now; ok is false on a miss.
+// The returned message carries the mode the text was written in. Every successful take asks the
+// worker for a refill.
+func (p *MessagePool) Take(userID int64, item models.Item, modes []models.UserMode, timeOfDay string) (*models.PooledMessage, bool, error) {
+	if p == nil {
+		return nil, false, nil
+	}
+	message, ok, err := p.repo.Pop(userID, item, modes, timeOfDay)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	p.RequestRefill()
+	return message, true, nil
+}
+
+// PoolModes lists the modes whose pooled texts may serve a nudge in the picked mode. MIX_MODE
+// picks a random mode per nudge, so a text of any of its candidates fits; other modes, AUTO_MODE
+// included, resolve to one mode for the time of day.
+func PoolModes(user models.User, picked models.UserMode) []models.UserMode {
+	if user.Mode == constants.MixMode {
+		return modes.Candidates(user.Mode, user.AutoModeMap)
+	}
+	return []models.UserMode{picked}
+}
+
+// RequestRefill wakes the worker without blocking; pending requests collapse into one.
+func (p *MessagePool) RequestRefill() {
+	if p == nil {
+		return
+	}
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+// PoolWorker refills the pool for users whose next nudge is within MessagePoolHorizon and
+// drops texts that went stale because the mode, the item name or the note changed.
+type PoolWorker struct {
+	pool             *MessagePool
+	userService      *user.Service
+	itemsService     *items.Service
+	messageGenerator prompt.MessageGenerator
+	logger           logger.AppLogger
+}
+
+func NewPoolWorker(
+	pool *MessagePool,
+	userService *user.Service,
+	itemsService *items.Service,
+	messageGenerator prompt.MessageGenerator,
+	logger logger.AppLogger,
+) *PoolWorker {
+	return &PoolWorker{
+		pool:             pool,
+		userService:      userService,
+		itemsService:     itemsService,
+		messageGenerator: messageGenerator,
+		logger:           logger,
+	}
+}
+
+func (w *PoolWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(constants.MessagePoolRefillInterval)
+	defer ticker.Stop()
+
+	if ctx.Err() == nil {
+		w.processSafe()
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			w.processSafe()
+		case <-w.pool.refill:
+			w.processSafe()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *PoolWorker) processSafe() {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			w.logger.Error(fmt.Sprintf("Message pool worker panic: %v", recovered))
+		}
+	}()
+
+	w.process()
+}
+
+func (w *PoolWorker) process() {
+	if w.messageGenerator == nil {
+		return
+	}
+	nowUTC := time.Now().UTC()
+	users, err := w.userService.GetUsersForNotification(nowUTC.Add(constants.MessagePoolHorizon))
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("Error fetching users for message pool: %v", err))
+		return
+	}
+
+	budget := constants.MessagePoolMaxGenerationsPerTick
+	for _, user := range users {
+		if budget <= 0 {
+			return
+		}
+		budget -= w.refillUser(nowUTC, user, budget)
+	}
+}
+
+// refillUser tops up the user's pool for the time of day and mode of the next nudge and returns
+// how many texts it generated. For the mix mode texts of all candidate modes count towards the
+// pool size and every run fills a randomly picked mode.
+func (w *PoolWorker) refillUser(nowUTC time.Time, user models.User, budget int) int {
+	if user.TelegramID == 0 || user.NotificationsMuted {
+		return 0
+	}
+	itemList, err := w.itemsService.RotationItems(user.TelegramID)
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("Error fetching items for message pool userID=%d: %v", user.TelegramID, err))
+		return 0
+	}
+	active := items.ActiveItems(itemList, nowUTC)
+	if err := w.pool.repo.DeleteStale(user.TelegramID, modes.Candidates(user.Mode, user.AutoModeMap), active); err != nil {
+		w.logger.Error(fmt.Sprintf("Error dropping stale pooled messages for userID=%d: %v", user.TelegramID, err))
+		return 0
+	}
+
+	at := user.NextNotification
+	if at.Before(nowUTC) {
+		at = nowUTC
+	}
+	local := at.In(w.userLocation(user))
+	timeOfDay := helpers.TimeOfDay(local)
+	mode := helpers.ModeAt(user, local)
+	counts, err := w.pool.repo.CountByItem(user.TelegramID, PoolModes(user, mode), timeOfDay)
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("Error counting pooled messages for userID=%d: %v", user.TelegramID, err))
+		return 0
+	}
+
+	generated := 0
+	for _, item := range RefillPlan(active, counts, constants.MessagePoolSize, budget) {
+		input := newLLMInput(item, mode, timeOfDay)
+		ctx, cancel := context.WithTimeout(prompt.WithUserID(context.Background(), user.TelegramID), llmGenerateTimeout)
+		text, err := w.messageGenerator.Generate(ctx, input)
+		cancel()
+		generated++
+		if errors.Is(err, prompt.ErrBudgetExhausted) {
+			w.logger.Debug(fmt.Sprintf("Message pool refill stopped for userID=%d: %v", user.TelegramID, err))
+			break
+		}
+		if err != nil {
+			w.logger.Debug(fmt.Sprintf("Message pool generation failed userID=%d itemID=%d: %v", user.TelegramID, item.ID, err))
+			continue
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		if err := w.pool.repo.Create(&models.PooledMessage{
+			UserID:    user.TelegramID,
+			ItemID:    item.ID,
+			Mode:      mode,
+			TimeOfDay: timeOfDay,
+			ItemName:  item.Name,
+			ItemNote:  item.Note,
+			Text:      text,
+		}); err != nil {
+			w.logger.Error(fmt.Sprintf("Error saving pooled message for userID=%d itemID=%d: %v", user.TelegramID, item.ID, err))
+		}
+	}
+	if generated > 0 {
+		w.logger.Debug(fmt.Sprintf("Message pool refilled userID=%d tod=%q generations=%d", user.TelegramID, timeOfDay, generated))
+	}
+	return generated
+}
+
+func (w *PoolWorker) userLocation(user models.User) *time.Location {
+	loc, err := helpers.UserLocation(user)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// RefillPlan lists one generation per missing text, emptiest items first, so that a small
+// budget spreads over many items instead of filling one. At most budget items are returned.
+func RefillPlan(itemList []models.Item, counts map[uint]int, size int, budget int) []models.Item {
+	type gap struct {
+		item    models.Item
+		missing int
+	}
+	gaps := make([]gap, 0, len(itemList))
+	for _, item := range itemList {
+		if missing := size - counts[item.ID]; missing > 0 {
+			gaps = append(gaps, gap{item: item, missing: missing})
+		}
+	}
+	sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].missing > gaps[j].missing })
+
+	plan := make([]models.Item, 0, budget)
+	for round := 0; len(plan) < budget; round++ {
+		added := false
+		for _, g := range gaps {
+			if len(plan) >= budget {
+				break
+			}
+			if g.missing > round {
+				plan = append(plan, g.item)
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	return plan
+}
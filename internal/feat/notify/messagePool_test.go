@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/models"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestRefillPlan(t *testing.T) {
+	itemList := []models.Item{
+		{Model: gorm.Model{ID: 1}},
+		{Model: gorm.Model{ID: 2}},
+		{Model: gorm.Model{ID: 3}},
+	}
+	counts := map[uint]int{1: 2, 2: 1}
+
+	plan := RefillPlan(itemList, counts, 2, 10)
+	ids := make([]uint, 0, len(plan))
+	for _, item := range plan {
+		ids = append(ids, item.ID)
+	}
+	if len(ids) != 3 || ids[0] != 3 || ids[1] != 2 || ids[2] != 3 {
+		t.Fatalf("expected emptiest items first without the full one, got %v", ids)
+	}
+
+	if plan := RefillPlan(itemList, counts, 2, 1); len(plan) != 1 || plan[0].ID != 3 {
+		t.Fatalf("expected the budget to cap the plan, got %d items", len(plan))
+	}
+	if plan := RefillPlan(itemList, map[uint]int{1: 2, 2: 2, 3: 2}, 2, 10); len(plan) != 0 {
+		t.Fatalf("expected no generations for a full pool, got %d", len(plan))
+	}
+}
+
+func TestPoolModes(t *testing.T) {
+	mix := models.User{Mode: constants.MixMode}
+	if got := PoolModes(mix, constants.CareMode); len(got) < 3 {
+		t.Fatalf("expected every mix candidate, got %v", got)
+	}
+	auto := models.User{Mode: constants.AutoMode, AutoModeMap: "morning=CARE_MODE"}
+	if got := PoolModes(auto, constants.CareMode); len(got) != 1 || got[0] != constants.CareMode {
+		t.Fatalf("expected only the picked auto mode, got %v", got)
+	}
+}
@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TextSimilarity compares two nudges by the Jaccard index of their word bigrams after
+// lowercasing and dropping punctuation and emoji. Texts of a single word fall back to
+// comparing words. The result is between 0 (nothing shared) and 1 (same wording).
+func TextSimilarity(a, b string) float64 {
+	shinglesA := shingles(tokens(a))
+	shinglesB := shingles(tokens(b))
+	if len(shinglesA) == 0 || len(shinglesB) == 0 {
+		return 0
+	}
+	shared := 0
+	for shingle := range shinglesA {
+		if _, ok := shinglesB[shingle]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(shinglesA)+len(shinglesB)-shared)
+}
+
+// TooSimilar reports whether text repeats the wording of any of the recent texts.
+func TooSimilar(text string, recent []string, threshold float64) bool {
+	for _, previous := range recent {
+		if TextSimilarity(text, previous) >= threshold {
+			return true
+		}
+	}
+	return false
+}
+
+func tokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func shingles(words []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(words))
+	if len(words) == 1 {
+		set[words[0]] = struct{}{}
+		return set
+	}
+	for i := 0; i+1 < len(words); i++ {
+		set[words[i]+" "+words[i+1]] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,28 @@
+package notify
+
+import "testing"
+
+func TestTextSimilarity(t *testing.T) {
+	if got := TextSimilarity("Не забудь про зонт! ☔", "не забудь, про ЗОНТ"); got != 1 {
+		t.Fatalf("expected punctuation, case and emoji to be ignored, got %f", got)
+	}
+	if got := TextSimilarity("Пора проверить зонт", "Зонт скучает в углу"); got != 0 {
+		t.Fatalf("expected different wording to share nothing, got %f", got)
+	}
+	if got := TextSimilarity("", "зонт"); got != 0 {
+		t.Fatalf("expected an empty text to share nothing, got %f", got)
+	}
+	if got := TextSimilarity("зонт", "Зонт!"); got != 1 {
+		t.Fatalf("expected single words to be compared directly, got %f", got)
+	}
+}
+
+func TestTooSimilar(t *testing.T) {
+	recent := []string{"Зонт скучает в углу", "Не забудь про зонт сегодня"}
+	if !TooSimilar("Не забудь про зонт сегодня вечером", recent, 0.6) {
+		t.Fatal("expected a near repeat to be caught")
+	}
+	if TooSimilar("Дождь обещают к обеду, зонт пригодится", recent, 0.6) {
+		t.Fatal("expected fresh wording to pass")
+	}
+}
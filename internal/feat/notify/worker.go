@@ -2,6 +2,7 @@ package notify
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"safeboxtgbot/internal/helpers"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"safeboxtgbot/internal/core/logger"
 	"safeboxtgbot/internal/feat/items"
 	"safeboxtgbot/internal/feat/prompt"
+	"safeboxtgbot/internal/feat/tags"
 	"safeboxtgbot/internal/feat/user"
 	"safeboxtgbot/internal/repo"
 	"safeboxtgbot/models"
@@ -20,11 +22,16 @@ import (
 	"gopkg.in/telebot.v4"
 )
 
+// llmGenerateTimeout bounds one text generation, retries and provider fallbacks included.
+const llmGenerateTimeout = 500 * time.Second
+
 type Worker struct {
 	userService      *user.Service
 	itemsService     *items.Service
+	tagsService      *tags.Service
 	messageLogRepo   *repo.MessageLogRepo
 	messageGenerator prompt.MessageGenerator
+	pool             *MessagePool
 	bot              *b.Bot
 	logger           logger.AppLogger
 }
@@ -32,16 +39,20 @@ type Worker struct {
 func NewWorker(
 	userService *user.Service,
 	itemsService *items.Service,
+	tagsService *tags.Service,
 	messageLogRepo *repo.MessageLogRepo,
 	messageGenerator prompt.MessageGenerator,
+	pool *MessagePool,
 	bot *b.Bot,
 	logger logger.AppLogger,
 ) *Worker {
 	return &Worker{
 		userService:      userService,
 		itemsService:     itemsService,
+		tagsService:      tagsService,
 		messageLogRepo:   messageLogRepo,
 		messageGenerator: messageGenerator,
+		pool:             pool,
 		bot:              bot,
 		logger:           logger,
 	}
@@ -112,7 +123,7 @@ func (w *Worker) processUser(nowUTC time.Time, user models.User) {
 		return
 	}
 
-	itemList, err := w.itemsService.GetItemList(user.TelegramID)
+	itemList, err := w.itemsService.RotationItems(user.TelegramID)
 	if err != nil {
 		w.logger.Error(fmt.Sprintf("Error fetching itemList for userID=%d: %v", user.TelegramID, err))
 		w.updateNextNotification(user, w.retryAt(nowUTC))
@@ -133,15 +144,8 @@ func (w *Worker) processUser(nowUTC time.Time, user models.User) {
 		return
 	}
 
-	text := helpers.FallbackText(item.Name, constants.FallbackEmojis)
-	if w.messageGenerator != nil {
-		generated, err := w.generateText(nowUTC, user, *item)
-		if err != nil {
-			w.logger.Error(fmt.Sprintf("Error generating message for userID=%d: %v", user.TelegramID, err))
-		} else if strings.TrimSpace(generated) != "" {
-			text = generated
-		}
-	}
+	mode := helpers.ModeAt(user, nowUTC.In(w.userLocation(user)))
+	text, mode := w.composeText(nowUTC, user, mode, *item)
 	w.logger.Debug(fmt.Sprintf("UserID=%d selected itemID=%d name=%q", user.TelegramID, item.ID, item.Name))
 	if err := w.send(user.TelegramID, text); err != nil {
 		w.updateNextNotification(user, w.retryAt(nowUTC))
@@ -154,6 +158,7 @@ func (w *Worker) processUser(nowUTC time.Time, user models.User) {
 		ItemID: item.ID,
 		SentAt: nowUTC,
 		Text:   text,
+		Style:  helpers.ModeToStyle(mode),
 	}); err != nil {
 		w.logger.Error(fmt.Sprintf("Error logging message for userID=%d: %v", user.TelegramID, err))
 	}
@@ -161,12 +166,94 @@ func (w *Worker) processUser(nowUTC time.Time, user models.User) {
 	w.updateNextNotification(user, w.nextNotificationTime(user, nowUTC))
 }
 
-func (w *Worker) pickItem(user models.User, items []models.Item, nowUTC time.Time) *models.Item {
+// composeText takes a user phrase for the item's PhraseShare of nudges and otherwise a pre-generated
+// text from the pool, asking the LLM only on a pool miss. LLM texts that repeat one of the item's
+// recent nudges are regenerated up to RepetitionMaxAttempts times. When the LLM fails or keeps
+// repeating itself, a phrase is still preferred over the generic fallback text. The returned mode is
+// the one the text was written in, which differs from mode for a pooled MIX_MODE text.
+func (w *Worker) composeText(nowUTC time.Time, user models.User, mode models.UserMode, item models.Item) (string, models.UserMode) {
+	if item.PhraseShare > 0 && utils.RandomIndex(100) < int(item.PhraseShare) {
+		if phrase, ok := w.nextPhrase(user, item); ok {
+			return phrase, mode
+		}
+	}
+	recent := w.recentTexts(user, item)
+	if pooled, ok := w.takePooled(nowUTC, user, mode, item); ok {
+		if !TooSimilar(pooled.Text, recent, constants.RepetitionSimilarityThreshold) {
+			return pooled.Text, pooled.Mode
+		}
+		w.logger.Debug(fmt.Sprintf("UserID=%d itemID=%d pooled text repeats a recent nudge", user.TelegramID, item.ID))
+	}
+	if w.messageGenerator != nil {
+		for attempt := 1; attempt <= constants.RepetitionMaxAttempts; attempt++ {
+			generated, err := w.generateText(nowUTC, user, mode, item)
+			if errors.Is(err, prompt.ErrBudgetExhausted) {
+				w.logger.Debug(fmt.Sprintf("UserID=%d itemID=%d LLM budget exhausted, using a fallback text: %v", user.TelegramID, item.ID, err))
+				break
+			}
+			if err != nil {
+				w.logger.Error(fmt.Sprintf("Error generating message for userID=%d: %v", user.TelegramID, err))
+				break
+			}
+			if strings.TrimSpace(generated) == "" {
+				break
+			}
+			if !TooSimilar(generated, recent, constants.RepetitionSimilarityThreshold) {
+				return generated, mode
+			}
+			w.logger.Debug(fmt.Sprintf("UserID=%d itemID=%d generated text repeats a recent nudge attempt=%d", user.TelegramID, item.ID, attempt))
+		}
+	}
+	if phrase, ok := w.nextPhrase(user, item); ok {
+		return phrase, mode
+	}
+	return helpers.FallbackText(item.Name, helpers.ModeFallbackEmojis(mode)), mode
+}
+
+func (w *Worker) recentTexts(user models.User, item models.Item) []string {
+	texts, err := w.messageLogRepo.GetRecentTexts(user.TelegramID, item.ID, constants.RepetitionHistorySize)
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("Error fetching recent texts for userID=%d itemID=%d: %v", user.TelegramID, item.ID, err))
+		return nil
+	}
+	return texts
+}
+
+func (w *Worker) takePooled(nowUTC time.Time, user models.User, mode models.UserMode, item models.Item) (*models.PooledMessage, bool) {
+	timeOfDay := helpers.TimeOfDay(nowUTC.In(w.userLocation(user)))
+	message, ok, err := w.pool.Take(user.TelegramID, item, PoolModes(user, mode), timeOfDay)
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("Error taking pooled message for userID=%d itemID=%d: %v", user.TelegramID, item.ID, err))
+		return nil, false
+	}
+	if !ok {
+		w.pool.RequestRefill()
+		return nil, false
+	}
+	w.logger.Debug(fmt.Sprintf("UserID=%d itemID=%d served from message pool mode=%s", user.TelegramID, item.ID, message.Mode))
+	return message, true
+}
+
+func (w *Worker) nextPhrase(user models.User, item models.Item) (string, bool) {
+	phrase, ok, err := w.itemsService.NextPhrase(item, utils.RandomIndex)
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("Error picking phrase for userID=%d itemID=%d: %v", user.TelegramID, item.ID, err))
+		return "", false
+	}
+	return phrase, ok
+}
+
+func (w *Worker) pickItem(user models.User, itemList []models.Item, nowUTC time.Time) *models.Item {
+	active := w.eligibleItems(user, items.ActiveItems(itemList, nowUTC), nowUTC)
+	if len(active) == 0 {
+		return nil
+	}
+
 	since := nowUTC.Add(-time.Duration(constants.NotificationItemCooldownMinutes) * time.Minute)
 	recentIDs, err := w.messageLogRepo.GetRecentItemIDs(user.TelegramID, since)
 	if err != nil {
 		w.logger.Error(fmt.Sprintf("Error fetching recent items for userID=%d: %v", user.TelegramID, err))
-		return w.randomItem(items)
+		return w.randomItem(active)
 	}
 
 	recent := make(map[uint]struct{}, len(recentIDs))
@@ -174,24 +261,31 @@ func (w *Worker) pickItem(user models.User, items []models.Item, nowUTC time.Tim
 		recent[id] = struct{}{}
 	}
 
-	candidates := make([]models.Item, 0, len(items))
-	for _, item := range items {
+	candidates := make([]models.Item, 0, len(active))
+	for _, item := range active {
 		if _, ok := recent[item.ID]; !ok {
 			candidates = append(candidates, item)
 		}
 	}
 	if len(candidates) == 0 {
-		candidates = items
-	}
-
-	if len(candidates) == 0 {
-		return nil
+		candidates = active
 	}
 
 	selected := candidates[utils.RandomIndex(len(candidates))]
 	return &selected
 }
 
+// eligibleItems applies category toggles and time slots at the user's local time.
+func (w *Worker) eligibleItems(user models.User, itemList []models.Item, nowUTC time.Time) []models.Item {
+	local := nowUTC.In(w.userLocation(user))
+	eligible, err := w.tagsService.EligibleItems(user.TelegramID, itemList, local.Hour()*60+local.Minute())
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("Error fetching tags for userID=%d: %v", user.TelegramID, err))
+		return itemList
+	}
+	return eligible
+}
+
 func (w *Worker) randomItem(items []models.Item) *models.Item {
 	if len(items) == 0 {
 		return nil
@@ -215,18 +309,23 @@ func (w *Worker) updateNextNotification(user models.User, next time.Time) {
 	}
 }
 
-func (w *Worker) generateText(nowUTC time.Time, user models.User, item models.Item) (string, error) {
-	loc := w.userLocation(user)
-	localNow := nowUTC.In(loc)
-	input := prompt.LLMInput{
+func (w *Worker) generateText(nowUTC time.Time, user models.User, mode models.UserMode, item models.Item) (string, error) {
+	input := newLLMInput(item, mode, helpers.TimeOfDay(nowUTC.In(w.userLocation(user))))
+	ctx, cancel := context.WithTimeout(prompt.WithUserID(context.Background(), user.TelegramID), llmGenerateTimeout)
+	defer cancel()
+	return w.messageGenerator.Generate(ctx, input)
+}
+
+// newLLMInput is shared by live generation and the message pool so both ask for the same text.
+func newLLMInput(item models.Item, mode models.UserMode, timeOfDay string) prompt.LLMInput {
+	return prompt.LLMInput{
 		CurrentEntity: item.Name,
-		TimeOfDay:     helpers.TimeOfDay(localNow),
-		StyleMode:     helpers.ModeToStyle(user.Mode),
+		EntityNote:    item.Note,
+		TimeOfDay:     timeOfDay,
+		StyleMode:     helpers.ModeToStyle(mode),
+		StylePrompt:   helpers.ModeStylePrompt(mode),
 		RandomSeed:    utils.RandomIntRange(1, 1_000_000),
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Second)
-	defer cancel()
-	return w.messageGenerator.Generate(ctx, input)
 }
 
 func (w *Worker) retryAt(nowUTC time.Time) time.Time {
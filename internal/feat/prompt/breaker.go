@@ -0,0 +1,143 @@
+package prompt
+
+import (
+	"sync"
+	"time"
+)
+
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// ProviderHealth is a snapshot of one provider's circuit for logs and the admin command.
+type ProviderHealth struct {
+	Name                string
+	State               CircuitState
+	ConsecutiveFailures int
+	OpenedAt            time.Time
+	RetryAt             time.Time // when an open circuit lets the next half-open probe through
+	LastSuccessAt       time.Time
+	LastFailureAt       time.Time
+	LastError           string
+	RejectedOutputs     map[string]int // answers that failed validation, by rule
+	FlaggedOutputs      map[string]int // answers kept despite an advisory rule, by rule
+}
+
+// CircuitBreaker tracks consecutive failures of a provider. After threshold failures the circuit
+// opens and calls are rejected until cooldown has passed; then a single half-open probe decides
+// whether it closes again or stays open for another cooldown.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+	probing   bool
+	health    ProviderHealth
+}
+
+func NewCircuitBreaker(name string, threshold int, cooldown time.Duration, now func() time.Time) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       now,
+		health:    ProviderHealth{Name: name, State: CircuitClosed},
+	}
+}
+
+// Allow reports whether a call may go through now. An open circuit whose cooldown passed
+// turns half-open and admits exactly one probe until its result is recorded.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.health.State {
+	case CircuitOpen:
+		if b.now().Before(b.health.RetryAt) {
+			return false
+		}
+		b.health.State = CircuitHalfOpen
+		b.probing = true
+		return true
+	case CircuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the circuit; the returned state is the one before the call.
+func (b *CircuitBreaker) RecordSuccess() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	prev := b.health.State
+	b.probing = false
+	b.health.State = CircuitClosed
+	b.health.ConsecutiveFailures = 0
+	b.health.OpenedAt = time.Time{}
+	b.health.RetryAt = time.Time{}
+	b.health.LastSuccessAt = b.now()
+	return prev
+}
+
+// RecordFailure counts a failure and reports whether it opened the circuit.
+func (b *CircuitBreaker) RecordFailure(err error) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.probing = false
+	b.health.ConsecutiveFailures++
+	b.health.LastFailureAt = now
+	if err != nil {
+		b.health.LastError = err.Error()
+	}
+
+	if b.health.State == CircuitHalfOpen || (b.health.State == CircuitClosed && b.health.ConsecutiveFailures >= b.threshold) {
+		b.health.State = CircuitOpen
+		b.health.OpenedAt = now
+		b.health.RetryAt = now.Add(b.cooldown)
+		return true
+	}
+	return false
+}
+
+// RecordCanceled ends a call whose caller gave up before the provider answered. It is not
+// counted as a failure, but a half-open probe goes back to open for another cooldown so that
+// the next probe is not blocked forever.
+func (b *CircuitBreaker) RecordCanceled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.health.State != CircuitHalfOpen || !b.probing {
+		return
+	}
+	b.probing = false
+	b.health.State = CircuitOpen
+	b.health.RetryAt = b.now().Add(b.cooldown)
+}
+
+func (b *CircuitBreaker) Health() ProviderHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.health
+}
+
+// HealthReporter exposes provider circuit states, e.g. for the /llm_health admin command.
+type HealthReporter interface {
+	Health() []ProviderHealth
+}
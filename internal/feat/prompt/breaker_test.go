@@ -0,0 +1,81 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2025, time.May, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker("test", 2, time.Minute, func() time.Time { return now })
+	failure := errors.New("timeout")
+
+	if !breaker.Allow() || breaker.RecordFailure(failure) {
+		t.Fatal("first failure must keep the circuit closed")
+	}
+	if !breaker.Allow() || !breaker.RecordFailure(failure) {
+		t.Fatal("second failure must open the circuit")
+	}
+	if breaker.Allow() {
+		t.Fatal("open circuit must reject calls during cooldown")
+	}
+
+	now = now.Add(time.Minute)
+	if !breaker.Allow() {
+		t.Fatal("expected a half-open probe after cooldown")
+	}
+	if breaker.Allow() {
+		t.Fatal("only one probe may run while half-open")
+	}
+	if !breaker.RecordFailure(failure) || breaker.Health().State != CircuitOpen {
+		t.Fatal("failed probe must reopen the circuit")
+	}
+
+	now = now.Add(time.Minute)
+	if !breaker.Allow() {
+		t.Fatal("expected another probe after cooldown")
+	}
+	if prev := breaker.RecordSuccess(); prev != CircuitHalfOpen {
+		t.Fatalf("expected half-open before success, got %s", prev)
+	}
+	health := breaker.Health()
+	if health.State != CircuitClosed || health.ConsecutiveFailures != 0 || health.LastError != "timeout" {
+		t.Fatalf("unexpected health after recovery: %+v", health)
+	}
+}
+
+type generatorFunc func(ctx context.Context, req LLMRequest) (string, error)
+
+func (f generatorFunc) Generate(ctx context.Context, req LLMRequest) (string, error) {
+	return f(ctx, req)
+}
+
+func TestCanceledProbeReopensCircuit(t *testing.T) {
+	now := time.Date(2025, time.May, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker("test", 1, time.Minute, func() time.Time { return now })
+	breaker.RecordFailure(errors.New("timeout"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := MustNewLLMService([]LLMProvider{{Name: "test", Generator: generatorFunc(func(context.Context, LLMRequest) (string, error) {
+		cancel()
+		return "", context.Canceled
+	})}}, nil, nil)
+	service.breakers[0] = breaker
+
+	now = now.Add(time.Minute)
+	if _, err := service.Generate(ctx, LLMRequest{SystemPrompt: "s", UserPrompt: "u"}); err == nil {
+		t.Fatal("expected an error from the canceled probe")
+	}
+	health := breaker.Health()
+	if health.State != CircuitOpen || health.ConsecutiveFailures != 1 || !health.RetryAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("canceled probe must reopen without counting a failure, got %+v", health)
+	}
+
+	now = now.Add(time.Minute)
+	if !breaker.Allow() {
+		t.Fatal("expected a new probe after the next cooldown")
+	}
+}
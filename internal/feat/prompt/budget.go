@@ -0,0 +1,135 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdlog "log"
+	"sync"
+	"time"
+
+	"safeboxtgbot/internal/core/config"
+	"safeboxtgbot/internal/core/logger"
+)
+
+// ErrBudgetExhausted is returned instead of calling a provider once a daily budget is used up.
+var ErrBudgetExhausted = errors.New("llm budget exhausted")
+
+// UsageCounter reports the provider calls and tokens spent since a moment; repo.LLMCallRepo
+// implements it.
+type UsageCounter interface {
+	CountSince(since time.Time) (calls int64, tokens int64, err error)
+	CountUserSince(userID int64, since time.Time) (calls int64, tokens int64, err error)
+}
+
+// Budget enforces the daily LLM token and request limits, globally and per user. Limits of
+// zero are off. Days start at midnight in the budget timezone; the admin is told once per
+// exhausted limit per day through logger.Error.
+type Budget struct {
+	mu       sync.Mutex
+	limits   config.LLMBudgetConfig
+	loc      *time.Location
+	counter  UsageCounter
+	logger   logger.AppLogger
+	now      func() time.Time
+	day      time.Time
+	notified map[string]bool
+}
+
+func MustNewBudget(limits config.LLMBudgetConfig, counter UsageCounter, appLogger logger.AppLogger) *Budget {
+	if counter == nil {
+		stdlog.Fatal("llm usage counter is nil")
+	}
+	loc, err := time.LoadLocation(limits.Timezone)
+	if err != nil {
+		stdlog.Fatalf("invalid LLM_BUDGET_TIMEZONE %q: %v", limits.Timezone, err)
+	}
+	return newBudget(limits, loc, counter, appLogger, time.Now)
+}
+
+func newBudget(limits config.LLMBudgetConfig, loc *time.Location, counter UsageCounter, appLogger logger.AppLogger, now func() time.Time) *Budget {
+	return &Budget{
+		limits:   limits,
+		loc:      loc,
+		counter:  counter,
+		logger:   appLogger,
+		now:      now,
+		notified: make(map[string]bool),
+	}
+}
+
+// Check returns ErrBudgetExhausted when the global budget or the budget of the user tagged with
+// WithUserID is used up for today. Counting errors are logged and let the call through.
+func (b *Budget) Check(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	since := b.today().UTC()
+
+	if b.limits.DailyTokens > 0 || b.limits.DailyRequests > 0 {
+		calls, tokens, err := b.counter.CountSince(since)
+		if err != nil {
+			b.logError(fmt.Sprintf("Error counting LLM usage for the budget: %v", err))
+			return nil
+		}
+		if err := b.exceeded("global", calls, tokens, b.limits.DailyRequests, b.limits.DailyTokens); err != nil {
+			return err
+		}
+	}
+
+	userID := userIDFrom(ctx)
+	if userID == 0 || (b.limits.UserDailyTokens <= 0 && b.limits.UserDailyRequests <= 0) {
+		return nil
+	}
+	calls, tokens, err := b.counter.CountUserSince(userID, since)
+	if err != nil {
+		b.logError(fmt.Sprintf("Error counting LLM usage of userID=%d for the budget: %v", userID, err))
+		return nil
+	}
+	return b.exceeded(fmt.Sprintf("userID=%d", userID), calls, tokens, b.limits.UserDailyRequests, b.limits.UserDailyTokens)
+}
+
+// today returns the start of the current budget day and forgets yesterday's notices.
+func (b *Budget) today() time.Time {
+	local := b.now().In(b.loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, b.loc)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !day.Equal(b.day) {
+		b.day = day
+		b.notified = make(map[string]bool)
+	}
+	return day
+}
+
+func (b *Budget) exceeded(scope string, calls, tokens, maxCalls, maxTokens int64) error {
+	var detail string
+	switch {
+	case limitReached(calls, maxCalls):
+		detail = fmt.Sprintf("%s daily requests %d/%d", scope, calls, maxCalls)
+	case limitReached(tokens, maxTokens):
+		detail = fmt.Sprintf("%s daily tokens %d/%d", scope, tokens, maxTokens)
+	default:
+		return nil
+	}
+
+	b.mu.Lock()
+	first := !b.notified[scope]
+	b.notified[scope] = true
+	b.mu.Unlock()
+	if first {
+		b.logError(fmt.Sprintf("LLM budget exhausted (%s), using fallback texts until midnight %s", detail, b.loc))
+	}
+	return fmt.Errorf("%w: %s", ErrBudgetExhausted, detail)
+}
+
+func limitReached(used, limit int64) bool {
+	return limit > 0 && used >= limit
+}
+
+func (b *Budget) logError(message string) {
+	if b.logger != nil {
+		b.logger.Error(message)
+	}
+}
@@ -0,0 +1,91 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"safeboxtgbot/internal/core/config"
+)
+
+type fakeCounter struct {
+	calls, tokens         int64
+	userCalls, userTokens int64
+	since                 time.Time
+}
+
+func (c *fakeCounter) CountSince(since time.Time) (int64, int64, error) {
+	c.since = since
+	return c.calls, c.tokens, nil
+}
+
+func (c *fakeCounter) CountUserSince(_ int64, since time.Time) (int64, int64, error) {
+	c.since = since
+	return c.userCalls, c.userTokens, nil
+}
+
+type countingLogger struct{ errors int }
+
+func (l *countingLogger) Debug(string) {}
+func (l *countingLogger) Info(string)  {}
+func (l *countingLogger) Error(string) { l.errors++ }
+
+func TestBudget(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	now := time.Date(2025, time.May, 1, 22, 30, 0, 0, time.UTC) // 01:30 on May 2 in MSK
+	counter := &fakeCounter{}
+	log := &countingLogger{}
+	budget := newBudget(config.LLMBudgetConfig{DailyTokens: 1000, UserDailyRequests: 5}, loc, counter, log, func() time.Time { return now })
+	userCtx := WithUserID(context.Background(), 42)
+
+	if err := budget.Check(userCtx); err != nil {
+		t.Fatalf("unused budget must allow calls, got %v", err)
+	}
+	if want := time.Date(2025, time.May, 2, 0, 0, 0, 0, loc); !counter.since.Equal(want) {
+		t.Fatalf("budget day must start at local midnight %s, got %s", want, counter.since)
+	}
+
+	counter.userCalls = 5
+	if err := budget.Check(userCtx); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("user over request limit must be rejected, got %v", err)
+	}
+	if err := budget.Check(context.Background()); err != nil {
+		t.Fatalf("calls without a user only follow the global budget, got %v", err)
+	}
+
+	counter.tokens = 1000
+	for i := 0; i < 3; i++ {
+		if err := budget.Check(context.Background()); !errors.Is(err, ErrBudgetExhausted) {
+			t.Fatalf("global token limit must reject calls, got %v", err)
+		}
+	}
+	if log.errors != 2 {
+		t.Fatalf("admin must be told once per exhausted budget, got %d notices", log.errors)
+	}
+
+	now = now.Add(24 * time.Hour)
+	_ = budget.Check(context.Background())
+	if log.errors != 3 {
+		t.Fatalf("a new day must notify again, got %d notices", log.errors)
+	}
+}
+
+func TestNilBudgetAllows(t *testing.T) {
+	var budget *Budget
+	if err := budget.Check(context.Background()); err != nil {
+		t.Fatalf("nil budget must allow calls, got %v", err)
+	}
+}
+
+func TestSuggesterRespectsBudget(t *testing.T) {
+	budget := newBudget(config.LLMBudgetConfig{UserDailyRequests: 1}, time.UTC, &fakeCounter{userCalls: 1}, &countingLogger{}, time.Now)
+	suggester := &ItemSuggester{prompt: "p", budget: budget, maxNameLen: 40, llm: generatorFunc(func(context.Context, LLMRequest) (string, error) {
+		t.Fatal("the LLM must not be called over budget")
+		return "", nil
+	})}
+
+	if _, err := suggester.Suggest(WithUserID(context.Background(), 42), SuggestInput{Count: 3}); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("expected ErrBudgetExhausted, got %v", err)
+	}
+}
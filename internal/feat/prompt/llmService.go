@@ -6,48 +6,73 @@ import (
 	"fmt"
 	stdlog "log"
 	"strings"
+	"time"
 
+	"safeboxtgbot/internal/core/constants"
 	"safeboxtgbot/internal/core/logger"
-
-	"github.com/goforj/godump"
-	"github.com/revrost/go-openrouter"
+	"safeboxtgbot/models"
 )
 
+// llmCallErrorMaxLen keeps recorded provider errors (which may include response bodies) short.
+const llmCallErrorMaxLen = 300
+
 type LLMRequest struct {
 	SystemPrompt string
 	UserPrompt   string
 	Temperature  float64
 	MaxTokens    int
+	// Validate, when set, checks a provider's answer. A rejected answer is counted for the
+	// provider and returned as an error without trying the next provider.
+	Validate func(text string) error
 }
 
+// LLMProvider is one named link of the LLMService chain.
+type LLMProvider struct {
+	Name      string
+	Generator LLMGenerator
+}
+
+// LLMService tries its providers in order and returns the first non-empty answer.
+// Each provider has a circuit breaker, so a provider that keeps failing is skipped
+// without waiting for its timeout until a half-open probe succeeds again.
 type LLMService struct {
-	client   *OpenRouterClient
-	model    string
-	fallback LLMGenerator
-	logger   logger.AppLogger
+	providers []LLMProvider
+	breakers  []*CircuitBreaker
+	stats     []*outputStats
+	usage     UsageRecorder
+	logger    logger.AppLogger
 }
 
 var _ LLMGenerator = (*LLMService)(nil)
 
-func MustNewLLMService(client *OpenRouterClient, model string, fallback LLMGenerator, appLogger logger.AppLogger) *LLMService {
-	if client == nil {
-		stdlog.Fatal("llm client is nil")
+// MustNewLLMService builds the chain; usage may be nil to skip recording calls.
+func MustNewLLMService(providers []LLMProvider, usage UsageRecorder, appLogger logger.AppLogger) *LLMService {
+	if len(providers) == 0 {
+		stdlog.Fatal("llm provider chain is empty")
 	}
-	trimmedModel := strings.TrimSpace(model)
-	if trimmedModel == "" {
-		stdlog.Fatal("llm model is empty")
+	for _, provider := range providers {
+		if provider.Generator == nil {
+			stdlog.Fatal(fmt.Sprintf("llm provider %q has no generator", provider.Name))
+		}
+	}
+	breakers := make([]*CircuitBreaker, len(providers))
+	stats := make([]*outputStats, len(providers))
+	for i, provider := range providers {
+		breakers[i] = NewCircuitBreaker(provider.Name, constants.LLMCircuitFailureThreshold, constants.LLMCircuitCooldown, time.Now)
+		stats[i] = newOutputStats()
 	}
 	return &LLMService{
-		client:   client,
-		model:    trimmedModel,
-		fallback: fallback,
-		logger:   appLogger,
+		providers: providers,
+		breakers:  breakers,
+		stats:     stats,
+		usage:     usage,
+		logger:    appLogger,
 	}
 }
 
 func (s *LLMService) Generate(ctx context.Context, req LLMRequest) (string, error) {
-	if s == nil || s.client == nil {
-		return "", errors.New("llm client is nil")
+	if s == nil || len(s.providers) == 0 {
+		return "", errors.New("llm provider chain is empty")
 	}
 	if strings.TrimSpace(req.SystemPrompt) == "" {
 		return "", errors.New("system prompt is empty")
@@ -56,96 +81,125 @@ func (s *LLMService) Generate(ctx context.Context, req LLMRequest) (string, erro
 		return "", errors.New("user prompt is empty")
 	}
 
-	resp, err := s.client.Chat(ctx, openrouter.ChatCompletionRequest{
-		Model: s.model,
-		Messages: []openrouter.ChatCompletionMessage{
-			openrouter.SystemMessage(req.SystemPrompt),
-			openrouter.UserMessage(req.UserPrompt),
-		},
-		Temperature: float32(req.Temperature),
-		MaxTokens:   req.MaxTokens,
-		ResponseFormat: &openrouter.ChatCompletionResponseFormat{
-			Type: openrouter.ChatCompletionResponseFormatTypeText,
-		},
-	})
-	if err != nil {
-		if s.logger != nil {
-			s.logger.Debug(fmt.Sprintf("LLM generate failed model=%s: %v", s.model, err))
+	var errs []error
+	for i, provider := range s.providers {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
 		}
-		return s.tryFallback(ctx, req, fmt.Errorf("OpenRouter chat failed: %w", err))
-	}
-	if len(resp.Choices) == 0 {
-		return s.tryFallback(ctx, req, errors.New("OpenRouter: empty choices"))
-	}
-
-	message := resp.Choices[0].Message
-	content := extractMessageContent(message)
-	if content == "" {
+		breaker := s.breakers[i]
+		if !breaker.Allow() {
+			errs = append(errs, fmt.Errorf("%s: circuit open", provider.Name))
+			continue
+		}
+		callCtx, usage := withCallUsage(ctx)
+		startedAt := time.Now()
+		result, err := provider.Generator.Generate(callCtx, req)
+		latency := time.Since(startedAt)
+		if err == nil && strings.TrimSpace(result) != "" {
+			s.recordSuccess(breaker)
+			if req.Validate != nil {
+				if err := req.Validate(result); err != nil {
+					s.stats[i].record(err)
+					if !IsAdvisory(err) {
+						s.recordCall(ctx, provider.Name, usage, startedAt, latency, models.LLMCallRejected, err)
+						if s.logger != nil {
+							s.logger.Debug(fmt.Sprintf("LLM provider %s output rejected: %v", provider.Name, err))
+						}
+						return "", fmt.Errorf("%s: %w", provider.Name, err)
+					}
+					if s.logger != nil {
+						s.logger.Debug(fmt.Sprintf("LLM provider %s output flagged and kept: %v", provider.Name, err))
+					}
+				}
+			}
+			s.recordCall(ctx, provider.Name, usage, startedAt, latency, models.LLMCallOK, nil)
+			if i > 0 && s.logger != nil {
+				s.logger.Debug(fmt.Sprintf("LLM provider %s succeeded after %d skipped or failed", provider.Name, i))
+			}
+			return result, nil
+		}
+		if err == nil {
+			err = errors.New("empty content")
+		}
+		s.recordCall(ctx, provider.Name, usage, startedAt, latency, models.LLMCallError, err)
 		if s.logger != nil {
-			s.logger.Error(godump.DumpJSONStr(resp))
+			s.logger.Debug(fmt.Sprintf("LLM provider %s failed: %v", provider.Name, err))
 		}
-		godump.DumpJSON(resp)
-		return s.tryFallback(ctx, req, errors.New("OpenRouter: empty content"))
-	}
-
-	if s.logger != nil {
-		s.logger.Debug(fmt.Sprintf("LLM generate succeeded model=%s content_len=%d", s.model, len(content)))
+		if ctx.Err() == nil {
+			s.recordFailure(breaker, err)
+		} else {
+			breaker.RecordCanceled()
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name, err))
 	}
-
-	return content, nil
+	return "", errors.Join(errs...)
 }
 
-func extractMessageContent(message openrouter.ChatCompletionMessage) string {
-	if trimmed := strings.TrimSpace(message.Content.Text); trimmed != "" {
-		return trimmed
+// Health returns the circuit state of every provider in chain order.
+func (s *LLMService) Health() []ProviderHealth {
+	if s == nil {
+		return nil
 	}
-	if len(message.Content.Multi) > 0 {
-		for _, part := range message.Content.Multi {
-			if part.Type == openrouter.ChatMessagePartTypeText {
-				if trimmed := strings.TrimSpace(part.Text); trimmed != "" {
-					return trimmed
-				}
-			}
-		}
+	health := make([]ProviderHealth, 0, len(s.breakers))
+	for i, breaker := range s.breakers {
+		providerHealth := breaker.Health()
+		providerHealth.RejectedOutputs, providerHealth.FlaggedOutputs = s.stats[i].snapshot()
+		health = append(health, providerHealth)
 	}
-	return messageReasoning(message)
+	return health
 }
 
-func messageReasoning(message openrouter.ChatCompletionMessage) string {
-	if message.Reasoning != nil {
-		if trimmed := strings.TrimSpace(*message.Reasoning); trimmed != "" {
-			return trimmed
-		}
+func (s *LLMService) recordCall(ctx context.Context, provider string, usage *callUsage, startedAt time.Time, latency time.Duration, outcome string, callErr error) {
+	if s.usage == nil {
+		return
 	}
-	for _, detail := range message.ReasoningDetails {
-		if trimmed := strings.TrimSpace(detail.Text); trimmed != "" {
-			return trimmed
-		}
-		if trimmed := strings.TrimSpace(detail.Summary); trimmed != "" {
-			return trimmed
-		}
+	call := &models.LLMCall{
+		CalledAt:         startedAt.UTC(),
+		UserID:           userIDFrom(ctx),
+		Provider:         provider,
+		LLMModel:         usage.model,
+		Outcome:          outcome,
+		PromptTokens:     usage.promptTokens,
+		CompletionTokens: usage.completionTokens,
+		LatencyMs:        latency.Milliseconds(),
+	}
+	if callErr != nil {
+		call.Error = truncateRunes(callErr.Error(), llmCallErrorMaxLen)
+	}
+	if err := s.usage.Create(call); err != nil && s.logger != nil {
+		s.logger.Error(fmt.Sprintf("Error recording LLM call provider=%s: %v", provider, err))
 	}
-	return ""
 }
 
-func (s *LLMService) tryFallback(ctx context.Context, req LLMRequest, cause error) (string, error) {
-	if s.fallback == nil {
-		return "", cause
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
 	}
-	if s.logger != nil {
-		s.logger.Debug(fmt.Sprintf("OpenRouter failed, falling back: %v", cause))
+	return string(runes[:limit])
+}
+
+func (s *LLMService) recordSuccess(breaker *CircuitBreaker) {
+	if prev := breaker.RecordSuccess(); prev != CircuitClosed && s.logger != nil {
+		s.logger.Info(fmt.Sprintf("LLM provider %s recovered, circuit closed", breaker.Health().Name))
 	}
+}
 
-	result, err := s.fallback.Generate(ctx, req)
-	if err != nil {
-		if s.logger != nil {
-			s.logger.Debug(fmt.Sprintf("Fallback generate failed: %v", err))
-		}
-		return "", fmt.Errorf("%v; fallback: %w", cause, err)
+func (s *LLMService) recordFailure(breaker *CircuitBreaker, err error) {
+	if !breaker.RecordFailure(err) || s.logger == nil {
+		return
 	}
+	health := breaker.Health()
+	s.logger.Error(fmt.Sprintf("LLM provider %s circuit opened after %d consecutive failures, next probe at %s: %v",
+		health.Name, health.ConsecutiveFailures, health.RetryAt.Format(time.RFC3339), err))
+}
 
-	if s.logger != nil {
-		s.logger.Debug("Fallback generate succeeded")
+// Fallback returns the chain without its first provider, or nil when there is nothing to fall back to.
+// /preview_llm uses it to exercise the fallback path on demand.
+func (s *LLMService) Fallback() LLMGenerator {
+	if s == nil || len(s.providers) < 2 {
+		return nil
 	}
-	return result, nil
+	return &LLMService{providers: s.providers[1:], breakers: s.breakers[1:], stats: s.stats[1:], usage: s.usage, logger: s.logger}
 }
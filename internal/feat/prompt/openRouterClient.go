@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"safeboxtgbot/internal/core/constants"
 	"safeboxtgbot/internal/core/logger"
 
 	"github.com/revrost/go-openrouter"
@@ -16,6 +17,7 @@ import (
 
 type OpenRouterClient struct {
 	client *openrouter.Client
+	retry  retryPolicy
 	logger logger.AppLogger
 }
 
@@ -45,10 +47,17 @@ func MustNewOpenRouterClient(apiKey string, appLogger logger.AppLogger) *OpenRou
 
 	return &OpenRouterClient{
 		client: client,
+		retry: retryPolicy{
+			maxAttempts: constants.LLMRetryMaxAttempts,
+			baseDelay:   constants.LLMRetryBaseDelay,
+			maxDelay:    constants.LLMRetryMaxDelay,
+		},
 		logger: appLogger,
 	}
 }
 
+// Chat sends the request and retries transient failures with the same policy as OpenAIClient.
+// The SDK does not expose Retry-After, so only the exponential backoff applies.
 func (c *OpenRouterClient) Chat(ctx context.Context, reqBody openrouter.ChatCompletionRequest) (openrouter.ChatCompletionResponse, error) {
 	if c == nil || c.client == nil {
 		return openrouter.ChatCompletionResponse{}, errors.New("OpenRouter client is nil")
@@ -56,5 +65,31 @@ func (c *OpenRouterClient) Chat(ctx context.Context, reqBody openrouter.ChatComp
 	if c.logger != nil {
 		c.logger.Debug(fmt.Sprintf("OpenRouter chat request model=%s messages=%d", reqBody.Model, len(reqBody.Messages)))
 	}
-	return c.client.CreateChatCompletion(ctx, reqBody)
+
+	var resp openrouter.ChatCompletionResponse
+	attempt := 0
+	err := c.retry.do(ctx, func() error {
+		attempt++
+		if attempt > 1 && c.logger != nil {
+			c.logger.Debug(fmt.Sprintf("OpenRouter chat retry attempt=%d model=%s", attempt, reqBody.Model))
+		}
+		var callErr error
+		resp, callErr = c.client.CreateChatCompletion(ctx, reqBody)
+		return openRouterStatusError(callErr)
+	})
+	return resp, err
+}
+
+// openRouterStatusError turns the SDK's status errors into HTTPStatusError so that
+// IsRetryable can tell rate limits and server errors from final ones.
+func openRouterStatusError(err error) error {
+	var apiErr *openrouter.APIError
+	if errors.As(err, &apiErr) && apiErr.HTTPStatusCode != 0 {
+		return &HTTPStatusError{Provider: "OpenRouter", StatusCode: apiErr.HTTPStatusCode, Body: apiErr.Error()}
+	}
+	var reqErr *openrouter.RequestError
+	if errors.As(err, &reqErr) && reqErr.HTTPStatusCode != 0 {
+		return &HTTPStatusError{Provider: "OpenRouter", StatusCode: reqErr.HTTPStatusCode, Body: reqErr.Error()}
+	}
+	return err
 }
@@ -0,0 +1,124 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdlog "log"
+	"strings"
+
+	"safeboxtgbot/internal/core/logger"
+
+	"github.com/goforj/godump"
+	"github.com/revrost/go-openrouter"
+)
+
+var _ LLMGenerator = (*OpenRouterService)(nil)
+
+type OpenRouterService struct {
+	client *OpenRouterClient
+	model  string
+	logger logger.AppLogger
+}
+
+func MustNewOpenRouterService(client *OpenRouterClient, model string, appLogger logger.AppLogger) *OpenRouterService {
+	if client == nil {
+		stdlog.Fatal("OpenRouter client is nil")
+	}
+	trimmedModel := strings.TrimSpace(model)
+	if trimmedModel == "" {
+		stdlog.Fatal("OpenRouter model is empty")
+	}
+	return &OpenRouterService{
+		client: client,
+		model:  trimmedModel,
+		logger: appLogger,
+	}
+}
+
+func (s *OpenRouterService) Generate(ctx context.Context, req LLMRequest) (string, error) {
+	if s == nil || s.client == nil {
+		return "", errors.New("OpenRouter client is nil")
+	}
+	if strings.TrimSpace(req.SystemPrompt) == "" {
+		return "", errors.New("system prompt is empty")
+	}
+	if strings.TrimSpace(req.UserPrompt) == "" {
+		return "", errors.New("user prompt is empty")
+	}
+
+	reportUsage(ctx, s.model, 0, 0)
+	resp, err := s.client.Chat(ctx, openrouter.ChatCompletionRequest{
+		Model: s.model,
+		Messages: []openrouter.ChatCompletionMessage{
+			openrouter.SystemMessage(req.SystemPrompt),
+			openrouter.UserMessage(req.UserPrompt),
+		},
+		Temperature: float32(req.Temperature),
+		MaxTokens:   req.MaxTokens,
+		ResponseFormat: &openrouter.ChatCompletionResponseFormat{
+			Type: openrouter.ChatCompletionResponseFormatTypeText,
+		},
+	})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Debug(fmt.Sprintf("OpenRouter generate failed model=%s: %v", s.model, err))
+		}
+		return "", fmt.Errorf("OpenRouter chat failed: %w", err)
+	}
+	if resp.Usage != nil {
+		reportUsage(ctx, resp.Model, resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+	}
+	if len(resp.Choices) == 0 {
+		return "", errors.New("OpenRouter: empty choices")
+	}
+
+	message := resp.Choices[0].Message
+	content := extractMessageContent(message)
+	if content == "" {
+		if s.logger != nil {
+			s.logger.Error(godump.DumpJSONStr(resp))
+		}
+		godump.DumpJSON(resp)
+		return "", errors.New("OpenRouter: empty content")
+	}
+
+	if s.logger != nil {
+		s.logger.Debug(fmt.Sprintf("OpenRouter generate succeeded model=%s content_len=%d", s.model, len(content)))
+	}
+
+	return content, nil
+}
+
+func extractMessageContent(message openrouter.ChatCompletionMessage) string {
+	if trimmed := strings.TrimSpace(message.Content.Text); trimmed != "" {
+		return trimmed
+	}
+	if len(message.Content.Multi) > 0 {
+		for _, part := range message.Content.Multi {
+			if part.Type == openrouter.ChatMessagePartTypeText {
+				if trimmed := strings.TrimSpace(part.Text); trimmed != "" {
+					return trimmed
+				}
+			}
+		}
+	}
+	return messageReasoning(message)
+}
+
+func messageReasoning(message openrouter.ChatCompletionMessage) string {
+	if message.Reasoning != nil {
+		if trimmed := strings.TrimSpace(*message.Reasoning); trimmed != "" {
+			return trimmed
+		}
+	}
+	for _, detail := range message.ReasoningDetails {
+		if trimmed := strings.TrimSpace(detail.Text); trimmed != "" {
+			return trimmed
+		}
+		if trimmed := strings.TrimSpace(detail.Summary); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
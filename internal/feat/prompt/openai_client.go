@@ -0,0 +1,175 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	stdlog "log"
+	"net/http"
+	"strings"
+	"time"
+
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/core/logger"
+)
+
+const (
+	groqBaseURL             = "https://api.groq.com/openai/v1"
+	defaultProviderTimeout  = 15 * time.Second
+	chatCompletionsEndpoint = "/chat/completions"
+)
+
+// OpenAIClient talks to any server implementing the OpenAI chat completions API:
+// Groq, Ollama, llama.cpp, vLLM and similar.
+type OpenAIClient struct {
+	httpClient *http.Client
+	name       string
+	url        string
+	apiKey     string
+	retry      retryPolicy
+	logger     logger.AppLogger
+}
+
+type OpenAIChatRequest struct {
+	Model               string                 `json:"model"`
+	Messages            []OpenAIChatMessage    `json:"messages"`
+	Temperature         *float64               `json:"temperature,omitempty"`
+	MaxTokens           *int                   `json:"max_tokens,omitempty"`
+	MaxCompletionTokens *int                   `json:"max_completion_tokens,omitempty"`
+	TopP                *float64               `json:"top_p,omitempty"`
+	Metadata            map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type OpenAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type OpenAIChatResponse struct {
+	ID      string             `json:"id"`
+	Object  string             `json:"object"`
+	Created int64              `json:"created"`
+	Model   string             `json:"model"`
+	Choices []OpenAIChatChoice `json:"choices"`
+	Usage   *OpenAIUsage       `json:"usage,omitempty"`
+}
+
+type OpenAIChatChoice struct {
+	Index        int               `json:"index"`
+	Message      OpenAIChatMessage `json:"message"`
+	FinishReason string            `json:"finish_reason"`
+}
+
+type OpenAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// MustNewOpenAIClient creates a client for baseURL (e.g. http://localhost:11434/v1).
+// An empty apiKey sends no Authorization header, which local servers usually expect;
+// a zero timeout falls back to defaultProviderTimeout.
+func MustNewOpenAIClient(name, baseURL, apiKey string, timeout time.Duration, appLogger logger.AppLogger) *OpenAIClient {
+	trimmedURL := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if trimmedURL == "" {
+		stdlog.Fatal(fmt.Sprintf("%s base url is empty", name))
+	}
+	if timeout <= 0 {
+		timeout = defaultProviderTimeout
+	}
+	return &OpenAIClient{
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		name:   name,
+		url:    trimmedURL + chatCompletionsEndpoint,
+		apiKey: strings.TrimSpace(apiKey),
+		retry: retryPolicy{
+			maxAttempts: constants.LLMRetryMaxAttempts,
+			baseDelay:   constants.LLMRetryBaseDelay,
+			maxDelay:    constants.LLMRetryMaxDelay,
+		},
+		logger: appLogger,
+	}
+}
+
+func MustNewGroqClient(apiKey string, appLogger logger.AppLogger) *OpenAIClient {
+	if strings.TrimSpace(apiKey) == "" {
+		stdlog.Fatal("Groq api key is empty")
+	}
+	return MustNewOpenAIClient("Groq", groqBaseURL, apiKey, defaultProviderTimeout, appLogger)
+}
+
+func (c *OpenAIClient) Name() string {
+	return c.name
+}
+
+// Chat sends the request and retries transient failures (429, 5xx, timeouts) with jittered
+// exponential backoff that honours Retry-After and never waits past the ctx deadline.
+func (c *OpenAIClient) Chat(ctx context.Context, req OpenAIChatRequest) (OpenAIChatResponse, error) {
+	if c == nil {
+		return OpenAIChatResponse{}, errors.New("OpenAI-compatible client is nil")
+	}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return OpenAIChatResponse{}, fmt.Errorf("marshal %s request: %w", c.name, err)
+	}
+
+	var out OpenAIChatResponse
+	attempt := 0
+	err = c.retry.do(ctx, func() error {
+		attempt++
+		if attempt > 1 && c.logger != nil {
+			c.logger.Debug(fmt.Sprintf("%s chat retry attempt=%d model=%s", c.name, attempt, req.Model))
+		}
+		var callErr error
+		out, callErr = c.chatOnce(ctx, req, payload)
+		return callErr
+	})
+	return out, err
+}
+
+func (c *OpenAIClient) chatOnce(ctx context.Context, req OpenAIChatRequest, payload []byte) (OpenAIChatResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return OpenAIChatResponse{}, fmt.Errorf("new %s request: %w", c.name, err)
+	}
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	if c.logger != nil {
+		c.logger.Debug(fmt.Sprintf("%s chat request model=%s messages=%d", c.name, req.Model, len(req.Messages)))
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return OpenAIChatResponse{}, fmt.Errorf("%s request failed: %w", c.name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return OpenAIChatResponse{}, fmt.Errorf("read %s response: %w", c.name, err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return OpenAIChatResponse{}, &HTTPStatusError{
+			Provider:   c.name,
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			Body:       strings.TrimSpace(string(body)),
+		}
+	}
+
+	var out OpenAIChatResponse
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&out); err != nil {
+		return OpenAIChatResponse{}, fmt.Errorf("decode %s response: %w", c.name, err)
+	}
+
+	return out, nil
+}
@@ -11,32 +11,33 @@ import (
 	"safeboxtgbot/internal/helpers"
 )
 
-var _ LLMGenerator = (*GroqService)(nil)
+var _ LLMGenerator = (*OpenAIService)(nil)
 
-type GroqService struct {
-	client *GroqClient
+// OpenAIService generates text with one model of an OpenAI-compatible provider.
+type OpenAIService struct {
+	client *OpenAIClient
 	model  string
 	logger logger.AppLogger
 }
 
-func MustNewGroqService(client *GroqClient, model string, appLogger logger.AppLogger) *GroqService {
+func MustNewOpenAIService(client *OpenAIClient, model string, appLogger logger.AppLogger) *OpenAIService {
 	if client == nil {
-		stdlog.Fatal("Groq client is nil")
+		stdlog.Fatal("OpenAI-compatible client is nil")
 	}
 	trimmedModel := strings.TrimSpace(model)
 	if trimmedModel == "" {
-		stdlog.Fatal("Groq model is empty")
+		stdlog.Fatal(fmt.Sprintf("%s model is empty", client.Name()))
 	}
-	return &GroqService{
+	return &OpenAIService{
 		client: client,
 		model:  trimmedModel,
 		logger: appLogger,
 	}
 }
 
-func (s *GroqService) Generate(ctx context.Context, req LLMRequest) (string, error) {
+func (s *OpenAIService) Generate(ctx context.Context, req LLMRequest) (string, error) {
 	if s == nil || s.client == nil {
-		return "", errors.New("Groq client is nil")
+		return "", errors.New("OpenAI-compatible client is nil")
 	}
 	if strings.TrimSpace(req.SystemPrompt) == "" {
 		return "", errors.New("system prompt is empty")
@@ -45,12 +46,12 @@ func (s *GroqService) Generate(ctx context.Context, req LLMRequest) (string, err
 		return "", errors.New("user prompt is empty")
 	}
 
-	messages := []GroqChatMessage{
+	messages := []OpenAIChatMessage{
 		{Role: "system", Content: req.SystemPrompt},
 		{Role: "user", Content: req.UserPrompt},
 	}
 
-	request := GroqChatCompletionRequest{
+	request := OpenAIChatRequest{
 		Model:    s.model,
 		Messages: messages,
 	}
@@ -61,25 +62,30 @@ func (s *GroqService) Generate(ctx context.Context, req LLMRequest) (string, err
 	temp := req.Temperature
 	request.Temperature = &temp
 
+	name := s.client.Name()
+	reportUsage(ctx, s.model, 0, 0)
 	resp, err := s.client.Chat(ctx, request)
 	if err != nil {
 		if s.logger != nil {
-			s.logger.Debug(fmt.Sprintf("Groq generate failed model=%s: %v", s.model, err))
+			s.logger.Debug(fmt.Sprintf("%s generate failed model=%s: %v", name, s.model, err))
 		}
 		return "", err
 	}
+	if resp.Usage != nil {
+		reportUsage(ctx, resp.Model, resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+	}
 	if len(resp.Choices) == 0 {
-		return "", errors.New("Groq: empty choices")
+		return "", fmt.Errorf("%s: empty choices", name)
 	}
 
 	content := strings.TrimSpace(resp.Choices[0].Message.Content)
 	clean := helpers.CleanLLMText(content)
 	if clean == "" {
-		return "", errors.New("Groq: empty content")
+		return "", fmt.Errorf("%s: empty content", name)
 	}
 
 	if s.logger != nil {
-		s.logger.Debug(fmt.Sprintf("Groq generate succeeded model=%s content_len=%d", s.model, len(clean)))
+		s.logger.Debug(fmt.Sprintf("%s generate succeeded model=%s content_len=%d", name, s.model, len(clean)))
 	}
 
 	return clean, nil
@@ -6,10 +6,16 @@ import (
 	"fmt"
 	stdlog "log"
 	"os"
+	"os/signal"
 	"safeboxtgbot/internal/helpers"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"safeboxtgbot/internal/core/constants"
 	"safeboxtgbot/internal/core/logger"
+	"safeboxtgbot/pkg/utils"
 )
 
 type MessageGenerator interface {
@@ -17,7 +23,7 @@ type MessageGenerator interface {
 }
 
 type PromptBuilder interface {
-	BuildSystem() string
+	BuildSystem(input LLMInput) (string, error)
 	BuildUser(input LLMInput) string
 }
 
@@ -27,47 +33,115 @@ type LLMGenerator interface {
 
 type LLMInput struct {
 	CurrentEntity string
+	EntityNote    string // optional user note about the entity, omitted from the prompt when empty
 	TimeOfDay     string
 	StyleMode     string
+	StylePrompt   string // mode registry rules for a StyleMode without its own template file
 	RandomSeed    int
+	Reminder      bool // selects the reminder prompt template instead of the StyleMode one
 }
 
-type defaultPromptBuilder struct {
-	prompt string
-	logger logger.AppLogger
+// TemplatePromptBuilder renders the system prompt from PROMPT_PATH: a directory with base.tmpl
+// and one file per style, or a single prompt file used for every style. The files are reloaded
+// when they change or on SIGHUP; an invalid version is reported and the previous one stays.
+type TemplatePromptBuilder struct {
+	path    string
+	mu      sync.RWMutex
+	set     *promptSet
+	seenSig string
+	logger  logger.AppLogger
 }
 
-func MustNewPromptBuilder(promptPath string, appLogger logger.AppLogger) PromptBuilder {
-	data, err := os.ReadFile(promptPath)
+func MustNewPromptBuilder(promptPath string, appLogger logger.AppLogger) *TemplatePromptBuilder {
+	set, err := loadPromptSet(promptPath)
 	if err != nil {
-		stdlog.Fatalf("read prompt: %v", err)
-	}
-	prompt := strings.TrimSpace(string(data))
-	if prompt == "" {
-		stdlog.Fatal("prompt is empty")
+		stdlog.Fatalf("load prompt: %v", err)
 	}
 
-	return &defaultPromptBuilder{
-		prompt: prompt,
-		logger: appLogger,
+	return &TemplatePromptBuilder{
+		path:    promptPath,
+		set:     set,
+		seenSig: set.signature,
+		logger:  appLogger,
 	}
 }
 
-func (b *defaultPromptBuilder) BuildSystem() string {
-	return b.prompt
+func (b *TemplatePromptBuilder) BuildSystem(input LLMInput) (string, error) {
+	style := input.StyleMode
+	if input.Reminder {
+		style = PromptStyleReminder
+	}
+	mode := input.StyleMode
+	if mode == "" {
+		mode = defaultPromptStyle
+	}
+	b.mu.RLock()
+	set := b.set
+	b.mu.RUnlock()
+	return set.render(style, PromptData{StyleMode: mode, StylePrompt: input.StylePrompt, TimeOfDay: input.TimeOfDay})
 }
 
-func (b *defaultPromptBuilder) BuildUser(input LLMInput) string {
+func (b *TemplatePromptBuilder) BuildUser(input LLMInput) string {
 	return buildUserPrompt(input)
 }
 
+// Reload loads and validates the prompt files and swaps them in; on error the current
+// version is kept.
+func (b *TemplatePromptBuilder) Reload() error {
+	set, err := loadPromptSet(b.path)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	b.set = set
+	b.mu.Unlock()
+	return nil
+}
+
+// Watch reloads the prompt on SIGHUP and when the files change, checking every
+// constants.PromptReloadInterval. Failed reloads go to logger.Error, which reaches the logger bot.
+func (b *TemplatePromptBuilder) Watch(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	ticker := time.NewTicker(constants.PromptReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-hangup:
+			b.reload("SIGHUP")
+		case <-ticker.C:
+			signature, err := promptSignature(b.path)
+			if err == nil && signature == b.seenSig {
+				continue
+			}
+			b.seenSig = signature
+			b.reload("file change")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *TemplatePromptBuilder) reload(reason string) {
+	if err := b.Reload(); err != nil {
+		b.logger.Error(fmt.Sprintf("Prompt reload (%s) failed, keeping the previous version: %v", reason, err))
+		return
+	}
+	b.logger.Info(fmt.Sprintf("Prompt reloaded from %s (%s)", b.path, reason))
+}
+
 type MessageOrchestrator struct {
 	builder PromptBuilder
 	llm     LLMGenerator
+	budget  *Budget
 	logger  logger.AppLogger
 }
 
-func MustNewMessageGenerator(builder PromptBuilder, llm LLMGenerator, appLogger logger.AppLogger) *MessageOrchestrator {
+// MustNewMessageGenerator builds the nudge generator; a nil budget means no limits.
+func MustNewMessageGenerator(builder PromptBuilder, llm LLMGenerator, budget *Budget, appLogger logger.AppLogger) *MessageOrchestrator {
 	if builder == nil {
 		stdlog.Fatal("prompt builder is nil")
 	}
@@ -78,6 +152,7 @@ func MustNewMessageGenerator(builder PromptBuilder, llm LLMGenerator, appLogger
 	return &MessageOrchestrator{
 		builder: builder,
 		llm:     llm,
+		budget:  budget,
 		logger:  appLogger,
 	}
 }
@@ -90,31 +165,57 @@ func (g *MessageOrchestrator) Generate(ctx context.Context, input LLMInput) (str
 		return "", errors.New("current_entity is empty")
 	}
 
-	raw, err := g.llm.Generate(ctx, LLMRequest{
-		SystemPrompt: g.builder.BuildSystem(),
-		UserPrompt:   g.builder.BuildUser(input),
-		Temperature:  1.2,
-		MaxTokens:    3000,
-	})
-	if err != nil {
-		return "", err
+	var lastErr error
+	for attempt := 1; attempt <= constants.LLMOutputMaxAttempts; attempt++ {
+		if attempt > 1 {
+			input.RandomSeed = utils.RandomIntRange(1, 1_000_000)
+		}
+		if err := g.budget.Check(ctx); err != nil {
+			return "", err
+		}
+		systemPrompt, err := g.builder.BuildSystem(input)
+		if err != nil {
+			return "", err
+		}
+		raw, err := g.llm.Generate(ctx, LLMRequest{
+			SystemPrompt: systemPrompt,
+			UserPrompt:   g.builder.BuildUser(input),
+			Temperature:  1.2,
+			MaxTokens:    3000,
+			Validate: func(text string) error {
+				return ValidateOutput(helpers.CleanLLMText(text), input)
+			},
+		})
+		if errors.Is(err, ErrOutputRejected) {
+			lastErr = err
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		text := helpers.CleanLLMText(raw)
+		if text == "" {
+			return "", errors.New("llm response is empty")
+		}
+
+		if g.logger != nil {
+			g.logger.Debug(fmt.Sprintf("PromptBuilder generated text for entity=%s attempt=%d", input.CurrentEntity, attempt))
+		}
+
+		return text, nil
 	}
-
-	text := helpers.CleanLLMText(raw)
-	if text == "" {
-		return "", errors.New("llm response is empty")
-	}
-
-	if g.logger != nil {
-		g.logger.Debug(fmt.Sprintf("PromptBuilder generated text for entity=%s", input.CurrentEntity))
-	}
-
-	return text, nil
+	return "", fmt.Errorf("no valid output after %d attempts: %w", constants.LLMOutputMaxAttempts, lastErr)
 }
 
 func buildUserPrompt(input LLMInput) string {
-	return fmt.Sprintf(`{"current_entity":%q,"time_of_day":%q,"style_mode":%q,"random_seed":%d}`,
+	note := ""
+	if input.EntityNote != "" {
+		note = fmt.Sprintf(`,"entity_note":%q`, input.EntityNote)
+	}
+	return fmt.Sprintf(`{"current_entity":%q%s,"time_of_day":%q,"style_mode":%q,"random_seed":%d}`,
 		input.CurrentEntity,
+		note,
 		input.TimeOfDay,
 		input.StyleMode,
 		input.RandomSeed,
@@ -0,0 +1,27 @@
+package prompt
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBuildUserPromptEntityNote(t *testing.T) {
+	input := LLMInput{CurrentEntity: "чай", TimeOfDay: "morning", StyleMode: "cozy", RandomSeed: 7}
+	if got := buildUserPrompt(input); strings.Contains(got, "entity_note") {
+		t.Fatalf("expected no entity_note for an empty note, got %s", got)
+	}
+
+	input.EntityNote = "зелёный \"сенча\"\nбез сахара"
+	got := buildUserPrompt(input)
+	if strings.Contains(got, "\n") {
+		t.Fatalf("expected the newline in the note to be escaped, got %s", got)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(got), &fields); err != nil {
+		t.Fatalf("user prompt is not valid JSON: %v\n%s", err, got)
+	}
+	if fields["entity_note"] != input.EntityNote || fields["current_entity"] != "чай" {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+}
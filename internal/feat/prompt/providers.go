@@ -0,0 +1,39 @@
+package prompt
+
+import (
+	"time"
+
+	"safeboxtgbot/internal/core/config"
+	"safeboxtgbot/internal/core/logger"
+)
+
+// MustNewProviderChain builds the ordered LLM provider list. When LLM_PROVIDERS is set it defines
+// the whole chain; otherwise the legacy pair is used: OpenRouter, then Groq if GROQ_API_KEY is set.
+func MustNewProviderChain(cfg *config.AppConfig, appLogger logger.AppLogger) []LLMProvider {
+	if len(cfg.LLMProviders) > 0 {
+		providers := make([]LLMProvider, 0, len(cfg.LLMProviders))
+		for _, provider := range cfg.LLMProviders {
+			timeout := time.Duration(provider.TimeoutSeconds) * time.Second
+			client := MustNewOpenAIClient(provider.Name, provider.BaseURL, provider.APIKey, timeout, appLogger)
+			providers = append(providers, LLMProvider{
+				Name:      provider.Name,
+				Generator: MustNewOpenAIService(client, provider.Model, appLogger),
+			})
+		}
+		return providers
+	}
+
+	openRouterClient := MustNewOpenRouterClient(cfg.OpenRouterModelApiKey, appLogger)
+	providers := []LLMProvider{{
+		Name:      "OpenRouter",
+		Generator: MustNewOpenRouterService(openRouterClient, cfg.OpenRouterModelName, appLogger),
+	}}
+	if cfg.GroqAPIKey != "" {
+		groqClient := MustNewGroqClient(cfg.GroqAPIKey, appLogger)
+		providers = append(providers, LLMProvider{
+			Name:      "Groq",
+			Generator: MustNewOpenAIService(groqClient, cfg.GroqModelName, appLogger),
+		})
+	}
+	return providers
+}
@@ -0,0 +1,118 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPStatusError is a non-2xx answer of an OpenAI-compatible provider.
+type HTTPStatusError struct {
+	Provider   string
+	StatusCode int
+	RetryAfter time.Duration // parsed Retry-After header; 0 when absent
+	Body       string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("%s status=%d body=%s", e.Provider, e.StatusCode, e.Body)
+}
+
+// retryPolicy bounds the retries of one Chat call.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// IsRetryable reports whether err is transient: rate limiting (429), a server error (5xx)
+// or a network timeout. Other 4xx answers and the caller's own cancellation are final.
+func IsRetryable(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// backoff returns the jittered exponential delay before retry number attempt (0-based),
+// or the server's Retry-After when it asks for longer. A Retry-After above maxDelay is not
+// worth waiting for: ok is false and the caller should give up.
+func (p retryPolicy) backoff(attempt int, err error) (delay time.Duration, ok bool) {
+	delay = p.baseDelay << attempt
+	if delay <= 0 || delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+	if half := int64(delay / 2); half > 0 {
+		delay = time.Duration(half + rand.Int64N(half+1))
+	}
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > delay {
+		if statusErr.RetryAfter > p.maxDelay {
+			return 0, false
+		}
+		delay = statusErr.RetryAfter
+	}
+	return delay, true
+}
+
+// do runs call until it succeeds, fails permanently, runs out of attempts, or the next wait
+// would end after the context deadline or exceed maxDelay.
+func (p retryPolicy) do(ctx context.Context, call func() error) error {
+	attempts := p.maxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = call(); err == nil || !IsRetryable(err) || attempt == attempts-1 {
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		delay, ok := p.backoff(attempt, err)
+		if !ok {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+	return err
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,194 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/revrost/go-openrouter"
+)
+
+const okChatBody = `{"choices":[{"index":0,"message":{"role":"assistant","content":"hi"}}]}`
+
+// newTestClient points an OpenAIClient at srv with short delays so retries finish quickly.
+func newTestClient(srv *httptest.Server, timeout time.Duration) *OpenAIClient {
+	client := MustNewOpenAIClient("test", srv.URL, "", timeout, nil)
+	client.retry = retryPolicy{maxAttempts: 3, baseDelay: time.Millisecond, maxDelay: 5 * time.Millisecond}
+	return client
+}
+
+// scriptedServer answers with the given statuses in order and with 200 afterwards.
+func scriptedServer(t *testing.T, calls *int32, statuses []int, header http.Header) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(calls, 1)) - 1
+		if r.URL.Path != chatCompletionsEndpoint {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if n < len(statuses) {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(statuses[n])
+			_, _ = w.Write([]byte(`{"error":"nope"}`))
+			return
+		}
+		_, _ = w.Write([]byte(okChatBody))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOpenAIClientRetriesTransientStatuses(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable} {
+		var calls int32
+		srv := scriptedServer(t, &calls, []int{status, status}, nil)
+
+		resp, err := newTestClient(srv, time.Second).Chat(context.Background(), OpenAIChatRequest{Model: "m"})
+		if err != nil {
+			t.Fatalf("status %d: unexpected error: %v", status, err)
+		}
+		if len(resp.Choices) != 1 || resp.Choices[0].Message.Content != "hi" {
+			t.Fatalf("status %d: unexpected response %+v", status, resp)
+		}
+		if calls != 3 {
+			t.Fatalf("status %d: expected 3 calls, got %d", status, calls)
+		}
+	}
+}
+
+func TestOpenAIClientDoesNotRetryClientErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound} {
+		var calls int32
+		srv := scriptedServer(t, &calls, []int{status}, nil)
+
+		_, err := newTestClient(srv, time.Second).Chat(context.Background(), OpenAIChatRequest{Model: "m"})
+		var statusErr *HTTPStatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != status {
+			t.Fatalf("status %d: expected HTTPStatusError, got %v", status, err)
+		}
+		if IsRetryable(err) || calls != 1 {
+			t.Fatalf("status %d: expected a single non-retryable call, got %d calls", status, calls)
+		}
+	}
+}
+
+func TestOpenAIClientGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	srv := scriptedServer(t, &calls, []int{503, 503, 503, 503}, nil)
+
+	_, err := newTestClient(srv, time.Second).Chat(context.Background(), OpenAIChatRequest{Model: "m"})
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected the last 503, got %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestOpenAIClientRetriesTimeouts(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+			return
+		}
+		_, _ = w.Write([]byte(okChatBody))
+	}))
+	t.Cleanup(srv.Close)
+
+	if _, err := newTestClient(srv, 50*time.Millisecond).Chat(context.Background(), OpenAIChatRequest{Model: "m"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected a retry after the timeout, got %d calls", calls)
+	}
+}
+
+func TestOpenAIClientHonoursRetryAfter(t *testing.T) {
+	var calls int32
+	srv := scriptedServer(t, &calls, []int{http.StatusTooManyRequests}, http.Header{"Retry-After": []string{"1"}})
+
+	client := newTestClient(srv, time.Second)
+	client.retry.maxDelay = 2 * time.Second
+	started := time.Now()
+	if _, err := client.Chat(context.Background(), OpenAIChatRequest{Model: "m"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if waited := time.Since(started); waited < time.Second {
+		t.Fatalf("expected to wait for Retry-After, waited %s", waited)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestOpenAIClientStopsBeforeContextDeadline(t *testing.T) {
+	var calls int32
+	srv := scriptedServer(t, &calls, []int{http.StatusTooManyRequests}, http.Header{"Retry-After": []string{"30"}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err := newTestClient(srv, time.Second).Chat(ctx, OpenAIChatRequest{Model: "m"})
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.RetryAfter != 30*time.Second {
+		t.Fatalf("expected the 429 with its Retry-After, got %v", err)
+	}
+	if time.Since(started) > 150*time.Millisecond || calls != 1 {
+		t.Fatalf("expected to give up at once, took %s with %d calls", time.Since(started), calls)
+	}
+}
+
+func TestOpenAIClientGivesUpOnLongRetryAfter(t *testing.T) {
+	var calls int32
+	srv := scriptedServer(t, &calls, []int{http.StatusTooManyRequests}, http.Header{"Retry-After": []string{"3600"}})
+
+	started := time.Now()
+	_, err := newTestClient(srv, time.Second).Chat(context.Background(), OpenAIChatRequest{Model: "m"})
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected the 429, got %v", err)
+	}
+	if time.Since(started) > 150*time.Millisecond || calls != 1 {
+		t.Fatalf("expected to give up without a deadline, took %s with %d calls", time.Since(started), calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, time.May, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"7":                             7 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Thu, 01 May 2025 12:00:30 GMT": 30 * time.Second,
+		"Thu, 01 May 2025 11:59:00 GMT": 0,
+	}
+	for value, want := range cases {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Fatalf("parseRetryAfter(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestOpenRouterStatusErrorIsRetryable(t *testing.T) {
+	cases := map[error]bool{
+		&openrouter.APIError{HTTPStatusCode: http.StatusTooManyRequests, Message: "slow down"}: true,
+		&openrouter.RequestError{HTTPStatusCode: http.StatusBadGateway}:                        true,
+		&openrouter.APIError{HTTPStatusCode: http.StatusUnauthorized, Message: "bad key"}:      false,
+	}
+	for err, want := range cases {
+		if got := IsRetryable(openRouterStatusError(err)); got != want {
+			t.Fatalf("IsRetryable(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdlog "log"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"safeboxtgbot/internal/core/logger"
+)
+
+var ErrSuggestionsInvalid = errors.New("llm suggestions are not a JSON list of strings")
+
+type SuggestInput struct {
+	CurrentItems []string
+	StyleMode    string
+	Count        int
+}
+
+// ItemSuggester asks the LLM for new item ideas using its own system prompt.
+type ItemSuggester struct {
+	prompt     string
+	llm        LLMGenerator
+	budget     *Budget
+	maxNameLen int
+	logger     logger.AppLogger
+}
+
+// MustNewItemSuggester builds the suggester; it shares the nudge budget, and a nil budget means no limits.
+func MustNewItemSuggester(promptPath string, llm LLMGenerator, budget *Budget, maxNameLen int, appLogger logger.AppLogger) *ItemSuggester {
+	if llm == nil {
+		stdlog.Fatal("llm generator is nil")
+	}
+	data, err := os.ReadFile(promptPath)
+	if err != nil {
+		stdlog.Fatalf("read suggest prompt: %v", err)
+	}
+	prompt := strings.TrimSpace(string(data))
+	if prompt == "" {
+		stdlog.Fatal("suggest prompt is empty")
+	}
+	return &ItemSuggester{prompt: prompt, llm: llm, budget: budget, maxNameLen: maxNameLen, logger: appLogger}
+}
+
+// Suggest returns up to input.Count normalized ideas that are not in input.CurrentItems.
+// It returns ErrBudgetExhausted without calling the LLM once the daily budget is used up.
+func (s *ItemSuggester) Suggest(ctx context.Context, input SuggestInput) ([]string, error) {
+	if err := s.budget.Check(ctx); err != nil {
+		return nil, err
+	}
+	userPrompt, err := json.Marshal(struct {
+		CurrentItems []string `json:"current_items"`
+		StyleMode    string   `json:"style_mode"`
+		Count        int      `json:"count"`
+	}{CurrentItems: input.CurrentItems, StyleMode: input.StyleMode, Count: input.Count})
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.llm.Generate(ctx, LLMRequest{
+		SystemPrompt: s.prompt,
+		UserPrompt:   string(userPrompt),
+		Temperature:  1.0,
+		MaxTokens:    1000,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ideas, err := ParseSuggestions(raw, input.CurrentItems, input.Count, s.maxNameLen)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Debug(fmt.Sprintf("Rejected item suggestions %q: %v", raw, err))
+		}
+		return nil, err
+	}
+	return ideas, nil
+}
+
+// ParseSuggestions accepts only a JSON array of strings, optionally inside a ```json fence.
+// Ideas are lowercased and space-collapsed; empty, too long, repeated and existing ones are dropped,
+// and the rest is capped at limit.
+func ParseSuggestions(raw string, existing []string, limit, maxNameLen int) ([]string, error) {
+	body := strings.TrimSpace(raw)
+	if strings.HasPrefix(body, "```") {
+		body = strings.TrimPrefix(body, "```")
+		body = strings.TrimPrefix(body, "json")
+		body = strings.TrimSuffix(strings.TrimSpace(body), "```")
+		body = strings.TrimSpace(body)
+	}
+	if !strings.HasPrefix(body, "[") {
+		return nil, ErrSuggestionsInvalid
+	}
+
+	var ideas []string
+	decoder := json.NewDecoder(bytes.NewReader([]byte(body)))
+	if err := decoder.Decode(&ideas); err != nil {
+		return nil, ErrSuggestionsInvalid
+	}
+	if decoder.More() {
+		return nil, ErrSuggestionsInvalid
+	}
+
+	seen := make(map[string]struct{}, len(existing)+len(ideas))
+	for _, name := range existing {
+		seen[normalizeSuggestion(name)] = struct{}{}
+	}
+	result := make([]string, 0, len(ideas))
+	for _, idea := range ideas {
+		name := normalizeSuggestion(idea)
+		if name == "" || utf8.RuneCountInString(name) > maxNameLen {
+			continue
+		}
+		if _, dup := seen[name]; dup {
+			continue
+		}
+		seen[name] = struct{}{}
+		result = append(result, name)
+		if len(result) == limit {
+			break
+		}
+	}
+	if len(result) == 0 {
+		return nil, ErrSuggestionsInvalid
+	}
+	return result, nil
+}
+
+func normalizeSuggestion(raw string) string {
+	return strings.ToLower(strings.Join(strings.Fields(raw), " "))
+}
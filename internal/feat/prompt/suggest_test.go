@@ -0,0 +1,39 @@
+package prompt
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseSuggestions(t *testing.T) {
+	raw := "```json\n[\"Чашка  какао\", \"чай\", \"прогулка\", \"\", \"прогулка\", \"очень-очень-очень длинная идея на много символов\"]\n```"
+	got, err := ParseSuggestions(raw, []string{"Чай"}, 10, 40)
+	if err != nil {
+		t.Fatalf("ParseSuggestions: %v", err)
+	}
+	want := []string{"чашка какао", "прогулка"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	capped, err := ParseSuggestions(`["a","b","c"]`, nil, 2, 40)
+	if err != nil || len(capped) != 2 {
+		t.Fatalf("expected 2 ideas, got %v (%v)", capped, err)
+	}
+}
+
+func TestParseSuggestionsRejectsNonList(t *testing.T) {
+	for _, raw := range []string{
+		`Вот идеи: ["a"]`,
+		`{"items":["a"]}`,
+		`["a", 1]`,
+		`["a"] и ещё`,
+		`["чай"]`,
+		``,
+	} {
+		if _, err := ParseSuggestions(raw, []string{"чай"}, 10, 40); !errors.Is(err, ErrSuggestionsInvalid) {
+			t.Fatalf("%q: expected ErrSuggestionsInvalid, got %v", raw, err)
+		}
+	}
+}
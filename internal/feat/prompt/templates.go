@@ -0,0 +1,162 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+const (
+	promptBaseFile      = "base.tmpl"
+	promptStyleTemplate = "style"
+	defaultPromptStyle  = "cozy"
+
+	// genericStyleKey is the template for styles from the mode registry without a file; its style
+	// part is genericStyleText, which renders the registry's prompt fragment.
+	genericStyleKey  = "*"
+	genericStyleText = "style_mode = \"{{.StyleMode}}\"\n{{.StylePrompt}}"
+
+	// PromptStyleReminder is the template used for reminders whatever the user's mode.
+	PromptStyleReminder = "reminder"
+)
+
+// PromptStyles are the per-style files a prompt directory must contain next to base.tmpl.
+var PromptStyles = []string{"rofl", "cozy", "care", PromptStyleReminder}
+
+// promptModes are the StyleMode values every template is test-rendered with on load.
+var promptModes = []string{"rofl", "cozy", "care"}
+
+// PromptData is what prompt templates can refer to.
+type PromptData struct {
+	StyleMode   string // the user's style, also for the reminder template
+	StylePrompt string // mode registry rules for the style, may be empty
+	TimeOfDay   string
+}
+
+// promptSet is one loaded version of the prompt. A directory gives one template per style,
+// each being base.tmpl with the style file as its "style" template; a single file is used
+// for every style.
+type promptSet struct {
+	templates map[string]*template.Template
+	signature string
+}
+
+func loadPromptSet(path string) (*promptSet, error) {
+	signature, err := promptSignature(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &promptSet{templates: make(map[string]*template.Template), signature: signature}
+	if !info.IsDir() {
+		tmpl, err := parsePromptFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		set.templates[""] = tmpl
+	} else {
+		for _, style := range PromptStyles {
+			tmpl, err := parsePromptFiles(filepath.Join(path, promptBaseFile), filepath.Join(path, style+".tmpl"))
+			if err != nil {
+				return nil, err
+			}
+			set.templates[style] = tmpl
+		}
+		tmpl, err := parsePromptFiles(filepath.Join(path, promptBaseFile))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tmpl.New(promptStyleTemplate).Parse(genericStyleText); err != nil {
+			return nil, err
+		}
+		set.templates[genericStyleKey] = tmpl
+	}
+
+	for style := range set.templates {
+		for _, mode := range promptModes {
+			if _, err := set.render(style, PromptData{StyleMode: mode, StylePrompt: "-", TimeOfDay: "morning"}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return set, nil
+}
+
+// parsePromptFiles parses the base file and, when given, the style file as its "style" template.
+func parsePromptFiles(basePath string, stylePath ...string) (*template.Template, error) {
+	data, err := os.ReadFile(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("read prompt: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(basePath)).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(basePath), err)
+	}
+	for _, path := range stylePath {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read prompt style: %w", err)
+		}
+		if _, err := tmpl.New(promptStyleTemplate).Parse(string(data)); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+		}
+	}
+	return tmpl, nil
+}
+
+func (s *promptSet) render(style string, data PromptData) (string, error) {
+	tmpl, ok := s.templates[style]
+	if !ok && data.StylePrompt != "" {
+		tmpl, ok = s.templates[genericStyleKey]
+	}
+	if !ok {
+		tmpl, ok = s.templates[defaultPromptStyle]
+	}
+	if !ok {
+		tmpl = s.templates[""]
+	}
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, data); err != nil {
+		return "", fmt.Errorf("render prompt style=%q mode=%q: %w", style, data.StyleMode, err)
+	}
+	prompt := strings.TrimSpace(builder.String())
+	if prompt == "" {
+		return "", fmt.Errorf("prompt style=%q mode=%q renders empty", style, data.StyleMode)
+	}
+	return prompt, nil
+}
+
+// promptSignature changes whenever one of the prompt files is edited, added or removed.
+func promptSignature(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return fileSignature(info), nil
+	}
+	files := []string{promptBaseFile}
+	for _, style := range PromptStyles {
+		files = append(files, style+".tmpl")
+	}
+	parts := make([]string, 0, len(files))
+	for _, name := range files {
+		info, err := os.Stat(filepath.Join(path, name))
+		if err != nil {
+			parts = append(parts, name+":missing")
+			continue
+		}
+		parts = append(parts, name+":"+fileSignature(info))
+	}
+	return strings.Join(parts, ";"), nil
+}
+
+func fileSignature(info os.FileInfo) string {
+	return fmt.Sprintf("%d@%d", info.Size(), info.ModTime().UnixNano())
+}
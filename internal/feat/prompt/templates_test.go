@@ -0,0 +1,96 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePromptDir(t *testing.T, base string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{promptBaseFile: base}
+	for _, style := range PromptStyles {
+		files[style+".tmpl"] = "style " + style + " for {{.StyleMode}}"
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadPromptSetRendersPerStyle(t *testing.T) {
+	set, err := loadPromptSet(writePromptDir(t, `base {{template "style" .}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := set.render("care", PromptData{StyleMode: "care"})
+	if err != nil || got != "base style care for care" {
+		t.Fatalf("unexpected care prompt %q: %v", got, err)
+	}
+	got, err = set.render(PromptStyleReminder, PromptData{StyleMode: "rofl"})
+	if err != nil || got != "base style reminder for rofl" {
+		t.Fatalf("unexpected reminder prompt %q: %v", got, err)
+	}
+	got, err = set.render("unknown", PromptData{StyleMode: "unknown"})
+	if err != nil || !strings.Contains(got, "style cozy") {
+		t.Fatalf("expected an unknown style to use the default template, got %q: %v", got, err)
+	}
+	got, err = set.render("motivation", PromptData{StyleMode: "motivation", StylePrompt: "бодро"})
+	if err != nil || got != "base style_mode = \"motivation\"\nбодро" {
+		t.Fatalf("expected a registry style to render its prompt fragment, got %q: %v", got, err)
+	}
+}
+
+func TestLoadPromptSetRejectsInvalid(t *testing.T) {
+	if _, err := loadPromptSet(writePromptDir(t, `base {{template "style" .`)); err == nil {
+		t.Fatal("expected a parse error")
+	}
+	if _, err := loadPromptSet(writePromptDir(t, `base {{.Missing}}`)); err == nil {
+		t.Fatal("expected an unknown field to fail validation")
+	}
+
+	dir := writePromptDir(t, `base {{template "style" .}}`)
+	if err := os.Remove(filepath.Join(dir, "care.tmpl")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadPromptSet(dir); err == nil {
+		t.Fatal("expected a missing style file to fail")
+	}
+}
+
+func TestReloadKeepsPreviousVersion(t *testing.T) {
+	dir := writePromptDir(t, `v1 {{template "style" .}}`)
+	builder := MustNewPromptBuilder(dir, nil)
+
+	if err := os.WriteFile(filepath.Join(dir, promptBaseFile), []byte(`v2 {{template "style"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Reload(); err == nil {
+		t.Fatal("expected the broken version to be rejected")
+	}
+	got, err := builder.BuildSystem(LLMInput{StyleMode: "rofl"})
+	if err != nil || !strings.HasPrefix(got, "v1 ") {
+		t.Fatalf("expected the previous version to stay, got %q: %v", got, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, promptBaseFile), []byte(`v2 {{template "style" .}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = builder.BuildSystem(LLMInput{StyleMode: "rofl", Reminder: true})
+	if got != "v2 style reminder for rofl" {
+		t.Fatalf("unexpected reloaded prompt %q", got)
+	}
+}
+
+func TestShippedPromptDirLoads(t *testing.T) {
+	if _, err := loadPromptSet(filepath.Join("..", "..", "..", "data", "prompts")); err != nil {
+		t.Fatal(err)
+	}
+}
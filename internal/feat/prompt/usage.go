@@ -0,0 +1,52 @@
+package prompt
+
+import (
+	"context"
+
+	"safeboxtgbot/models"
+)
+
+// UsageRecorder stores one row per provider call; repo.LLMCallRepo implements it.
+type UsageRecorder interface {
+	Create(call *models.LLMCall) error
+}
+
+type userIDKey struct{}
+
+type callUsageKey struct{}
+
+// callUsage is filled by a provider during one call so that LLMService can record it.
+type callUsage struct {
+	model            string
+	promptTokens     int
+	completionTokens int
+}
+
+// WithUserID marks the LLM calls made with ctx as made for the user, for /llm_usage.
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+func userIDFrom(ctx context.Context) int64 {
+	userID, _ := ctx.Value(userIDKey{}).(int64)
+	return userID
+}
+
+func withCallUsage(ctx context.Context) (context.Context, *callUsage) {
+	usage := &callUsage{}
+	return context.WithValue(ctx, callUsageKey{}, usage), usage
+}
+
+// reportUsage lets a provider tell LLMService which model answered and how many tokens it used.
+// An empty model keeps the one reported before.
+func reportUsage(ctx context.Context, model string, promptTokens, completionTokens int) {
+	usage, ok := ctx.Value(callUsageKey{}).(*callUsage)
+	if !ok {
+		return
+	}
+	if model != "" {
+		usage.model = model
+	}
+	usage.promptTokens = promptTokens
+	usage.completionTokens = completionTokens
+}
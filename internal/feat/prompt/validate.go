@@ -0,0 +1,184 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"safeboxtgbot/internal/core/constants"
+)
+
+var ErrOutputRejected = errors.New("llm output rejected")
+
+// Validation rules reported in OutputRejection.Rule and counted per provider.
+const (
+	RuleLength         = "length"
+	RuleEntityMissing  = "entity_missing"
+	RuleReasoning      = "reasoning"
+	RuleMarkup         = "markup"
+	RuleAssistantStyle = "assistant_style"
+)
+
+// OutputRejection is returned when a provider answered but the text breaks a validation rule.
+// An advisory rejection is only counted: LLMService keeps the text.
+type OutputRejection struct {
+	Rule     string
+	Detail   string
+	Advisory bool
+}
+
+func (e *OutputRejection) Error() string {
+	return fmt.Sprintf("%v (%s): %s", ErrOutputRejected, e.Rule, e.Detail)
+}
+
+func (e *OutputRejection) Unwrap() error {
+	return ErrOutputRejected
+}
+
+// IsAdvisory reports whether err is an advisory OutputRejection.
+func IsAdvisory(err error) bool {
+	var rejection *OutputRejection
+	return errors.As(err, &rejection) && rejection.Advisory
+}
+
+var (
+	reasoningMarkers = []string{"<think", "</think", "let me", "let's think", "i need to", "the user wants", "current_entity", "style_mode", "random_seed"}
+	markupMarkers    = []string{"**", "__", "`", "](", "\":"}
+	assistantOpeners = []string{"конечно", "вот вариант", "вот сообщение", "вот текст", "sure", "here is", "here's", "certainly", "извини"}
+	assistantMarkers = []string{"как ии", "как ассистент", "языковая модель", "я не могу", "чем могу помочь", "as an ai", "i'm sorry", "i cannot"}
+)
+
+// ValidateOutput checks a cleaned nudge against the rules the prompt asks for. It returns
+// an *OutputRejection naming the first broken rule, or nil for an acceptable text. The entity
+// rule is advisory: the prompt asks to paraphrase the entity, and a good paraphrase
+// ("окно" → "подоконник") shares no stem with it, so a missing entity is counted but kept.
+func ValidateOutput(text string, input LLMInput) error {
+	length := utf8.RuneCountInString(text)
+	if length < constants.LLMOutputMinLen || length > constants.LLMOutputMaxLen {
+		return &OutputRejection{Rule: RuleLength, Detail: fmt.Sprintf("%d runes", length)}
+	}
+	lower := strings.ToLower(text)
+	for _, marker := range reasoningMarkers {
+		if strings.Contains(lower, marker) {
+			return &OutputRejection{Rule: RuleReasoning, Detail: marker}
+		}
+	}
+	trimmed := strings.TrimSpace(lower)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "- ") {
+		return &OutputRejection{Rule: RuleMarkup, Detail: string([]rune(trimmed)[:1])}
+	}
+	for _, marker := range markupMarkers {
+		if strings.Contains(lower, marker) {
+			return &OutputRejection{Rule: RuleMarkup, Detail: marker}
+		}
+	}
+	for _, opener := range assistantOpeners {
+		if strings.HasPrefix(trimmed, opener) {
+			return &OutputRejection{Rule: RuleAssistantStyle, Detail: opener}
+		}
+	}
+	for _, marker := range assistantMarkers {
+		if strings.Contains(lower, marker) {
+			return &OutputRejection{Rule: RuleAssistantStyle, Detail: marker}
+		}
+	}
+	if !mentionsEntity(lower, input.CurrentEntity) {
+		return &OutputRejection{Rule: RuleEntityMissing, Detail: input.CurrentEntity, Advisory: true}
+	}
+	return nil
+}
+
+// entitySuffixMaxLen bounds how much longer than the entity word a matching word may be, so
+// that "кот" does not match "который".
+const entitySuffixMaxLen = 3
+
+// mentionsEntity accepts the entity as is or any word sharing a stem with one of its words and
+// at most entitySuffixMaxLen runes longer, which lets inflections and diminutives through
+// ("чай" → "чайку", "зонт" → "зонтик").
+// An entity without letters or digits (emoji, "??", or empty) cannot be checked and always passes.
+func mentionsEntity(lowerText, entity string) bool {
+	lowerEntity := strings.ToLower(strings.TrimSpace(entity))
+	entityWords := words(lowerEntity)
+	if len(entityWords) == 0 {
+		return true
+	}
+	// A whole phrase is specific enough to match as a substring; a single word is not ("кот" in "который").
+	if len(entityWords) > 1 && strings.Contains(lowerText, lowerEntity) {
+		return true
+	}
+	textWords := words(lowerText)
+	for _, entityWord := range entityWords {
+		stem := wordStem(entityWord)
+		maxLen := utf8.RuneCountInString(entityWord) + entitySuffixMaxLen
+		for _, textWord := range textWords {
+			if strings.HasPrefix(textWord, stem) && utf8.RuneCountInString(textWord) <= maxLen {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// wordStem keeps the first four runes of a long word and drops the last rune of a word of
+// four, a crude stem that is enough for Russian endings. Shorter words are kept whole: a
+// two-rune stem of "кот" would match "который" and "конечно".
+func wordStem(word string) string {
+	runes := []rune(word)
+	switch {
+	case len(runes) > 4:
+		return string(runes[:4])
+	case len(runes) == 4:
+		return string(runes[:3])
+	default:
+		return word
+	}
+}
+
+// outputStats counts rejected and flagged outputs of one provider by rule.
+type outputStats struct {
+	mu      sync.Mutex
+	byRule  map[string]int
+	flagged map[string]int
+}
+
+func newOutputStats() *outputStats {
+	return &outputStats{byRule: make(map[string]int), flagged: make(map[string]int)}
+}
+
+func (s *outputStats) record(err error) {
+	rule := "other"
+	var rejection *OutputRejection
+	if errors.As(err, &rejection) {
+		rule = rejection.Rule
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if IsAdvisory(err) {
+		s.flagged[rule]++
+		return
+	}
+	s.byRule[rule]++
+}
+
+func (s *outputStats) snapshot() (rejected, flagged map[string]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyCounts(s.byRule), copyCounts(s.flagged)
+}
+
+func copyCounts(counts map[string]int) map[string]int {
+	out := make(map[string]int, len(counts))
+	for rule, count := range counts {
+		out[rule] = count
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateOutput(t *testing.T) {
+	input := LLMInput{CurrentEntity: "чай"}
+	cases := []struct {
+		text string
+		rule string
+	}{
+		{text: "Чайку бы сейчас ☕️", rule: ""},
+		{text: "Тёплый чай ждёт 🫖", rule: ""},
+		{text: "ок", rule: RuleLength},
+		{text: strings.Repeat("чай ", 100), rule: RuleLength},
+		{text: "<think>надо про чай</think>", rule: RuleReasoning},
+		{text: "Let me write about чай", rule: RuleReasoning},
+		{text: `{"text":"чай"}`, rule: RuleMarkup},
+		{text: "**чай** ждёт", rule: RuleMarkup},
+		{text: "Конечно! Вот про чай ☕️", rule: RuleAssistantStyle},
+		{text: "Как ИИ, я люблю чай", rule: RuleAssistantStyle},
+	}
+	for _, c := range cases {
+		err := ValidateOutput(c.text, input)
+		if c.rule == "" {
+			if err != nil {
+				t.Errorf("%q: expected a valid text, got %v", c.text, err)
+			}
+			continue
+		}
+		var rejection *OutputRejection
+		if !errors.As(err, &rejection) || rejection.Rule != c.rule {
+			t.Errorf("%q: expected rule %s, got %v", c.text, c.rule, err)
+		}
+		if !errors.Is(err, ErrOutputRejected) {
+			t.Errorf("%q: expected ErrOutputRejected in the chain", c.text)
+		}
+	}
+}
+
+func TestValidateOutputUncheckableEntity(t *testing.T) {
+	if err := ValidateOutput("Загадка дня 🤔", LLMInput{CurrentEntity: "??"}); err != nil {
+		t.Fatalf("expected an entity without words to pass, got %v", err)
+	}
+	if err := ValidateOutput("Зонтик в прихожей скучает ☔", LLMInput{CurrentEntity: "Взять зонт"}); err != nil {
+		t.Fatalf("expected one matching word of the entity to pass, got %v", err)
+	}
+}
+
+func TestValidateOutputEntityIsAdvisory(t *testing.T) {
+	cases := []struct {
+		entity  string
+		text    string
+		flagged bool
+	}{
+		{entity: "окно", text: "Подоконник скучает без взгляда 🪟", flagged: true},
+		{entity: "кот", text: "Котик ждёт почесушек 🐈", flagged: false},
+		{entity: "кот", text: "Который час? Пора отдохнуть", flagged: true},
+		{entity: "кот", text: "Контора подождёт, пора отдохнуть", flagged: true},
+		{entity: "велосипед", text: "Покатаемся на велосипедике? 🚲", flagged: false},
+		{entity: "зонт", text: "Зонтик в прихожей скучает ☔", flagged: false},
+	}
+	for _, c := range cases {
+		err := ValidateOutput(c.text, LLMInput{CurrentEntity: c.entity})
+		if !c.flagged {
+			if err != nil {
+				t.Errorf("%s / %q: expected a valid text, got %v", c.entity, c.text, err)
+			}
+			continue
+		}
+		var rejection *OutputRejection
+		if !errors.As(err, &rejection) || rejection.Rule != RuleEntityMissing || !IsAdvisory(err) {
+			t.Errorf("%s / %q: expected an advisory %s, got %v", c.entity, c.text, RuleEntityMissing, err)
+		}
+	}
+}
+
+func TestLLMServiceKeepsFlaggedOutput(t *testing.T) {
+	service := MustNewLLMService([]LLMProvider{{Name: "test", Generator: generatorFunc(func(context.Context, LLMRequest) (string, error) {
+		return "Подоконник скучает без взгляда 🪟", nil
+	})}}, nil, nil)
+	input := LLMInput{CurrentEntity: "окно"}
+
+	text, err := service.Generate(context.Background(), LLMRequest{
+		SystemPrompt: "s",
+		UserPrompt:   "u",
+		Validate:     func(text string) error { return ValidateOutput(text, input) },
+	})
+	if err != nil || text == "" {
+		t.Fatalf("flagged output must be kept, got %q, %v", text, err)
+	}
+	health := service.Health()[0]
+	if health.FlaggedOutputs[RuleEntityMissing] != 1 || len(health.RejectedOutputs) != 0 {
+		t.Fatalf("expected one flagged output, got %+v", health)
+	}
+}
@@ -0,0 +1,148 @@
+package reminder
+
+import (
+	"errors"
+	"fmt"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/core/logger"
+	"safeboxtgbot/internal/feat/holiday"
+	"safeboxtgbot/internal/feat/user"
+	"safeboxtgbot/internal/repo"
+	"safeboxtgbot/internal/session"
+	"safeboxtgbot/models"
+	"time"
+)
+
+var (
+	ErrExcludedDateInvalid    = errors.New("excluded date invalid")
+	ErrExcludedDateLimit      = errors.New("excluded date limit reached")
+	ErrUnknownHolidayCalendar = errors.New("unknown holiday calendar")
+)
+
+// ExclusionChecker tells the scheduler whether a local calendar day is excluded for a user.
+type ExclusionChecker interface {
+	IsExcluded(userID int64, localDay time.Time) bool
+}
+
+// ExclusionService manages per-user skip dates and the optional bundled holiday calendar.
+type ExclusionService struct {
+	repo        *repo.ExcludedDateRepo
+	userService *user.Service
+	store       *session.Store
+	logger      logger.AppLogger
+}
+
+var _ ExclusionChecker = (*ExclusionService)(nil)
+
+func NewExclusionService(repo *repo.ExcludedDateRepo, userService *user.Service, store *session.Store, logger logger.AppLogger) *ExclusionService {
+	return &ExclusionService{repo: repo, userService: userService, store: store, logger: logger}
+}
+
+func (s *ExclusionService) GetList(userID int64) ([]models.ExcludedDate, error) {
+	if err := s.ensureLoaded(userID); err != nil {
+		return nil, err
+	}
+	return s.store.GetExcludedDates(userID), nil
+}
+
+// Add stores the calendar day of localDay as excluded. Only days from today on, in the location
+// of localDay, count toward the limit: past days are hidden from the user and cannot be removed.
+func (s *ExclusionService) Add(userID int64, localDay time.Time) error {
+	if err := s.ensureLoaded(userID); err != nil {
+		return err
+	}
+	if localDay.IsZero() {
+		return ErrExcludedDateInvalid
+	}
+	key := localDay.Format(time.DateOnly)
+	today := time.Now().In(localDay.Location()).Format(time.DateOnly)
+	upcoming := 0
+	for _, d := range s.store.GetExcludedDates(userID) {
+		if d.Date == key {
+			return nil
+		}
+		if d.Date >= today {
+			upcoming++
+		}
+	}
+	if upcoming >= constants.MaxExcludedDatesPerUser {
+		return ErrExcludedDateLimit
+	}
+	if err := s.repo.Upsert(&models.ExcludedDate{UserID: userID, Date: key}); err != nil {
+		return err
+	}
+	return s.refresh(userID)
+}
+
+func (s *ExclusionService) Remove(userID int64, date string) error {
+	if err := s.ensureLoaded(userID); err != nil {
+		return err
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return ErrExcludedDateInvalid
+	}
+	if _, err := s.repo.Delete(userID, date); err != nil {
+		return err
+	}
+	return s.refresh(userID)
+}
+
+// SetHolidayCalendar switches the bundled holiday calendar; an empty code turns it off.
+func (s *ExclusionService) SetHolidayCalendar(userID int64, code string) error {
+	if code != "" {
+		cal, ok := holiday.Lookup(code)
+		if !ok {
+			return ErrUnknownHolidayCalendar
+		}
+		code = cal.Code
+	}
+	return s.userService.UpdateHolidayCalendar(userID, code)
+}
+
+// PurgePast deletes excluded dates that are in the past in every timezone, i.e. before
+// yesterday in UTC. Cached session lists may keep them until reloaded; past days match nothing.
+func (s *ExclusionService) PurgePast(now time.Time) (int64, error) {
+	return s.repo.DeleteBefore(now.UTC().AddDate(0, 0, -1).Format(time.DateOnly))
+}
+
+func (s *ExclusionService) IsExcluded(userID int64, localDay time.Time) bool {
+	if s == nil {
+		return false
+	}
+	if userDTO := s.userService.GetUser(userID); userDTO != nil && userDTO.HolidayCalendar != "" {
+		if cal, ok := holiday.Lookup(userDTO.HolidayCalendar); ok && cal.IsHoliday(localDay) {
+			return true
+		}
+	}
+	if err := s.ensureLoaded(userID); err != nil {
+		return false
+	}
+	key := localDay.Format(time.DateOnly)
+	for _, d := range s.store.GetExcludedDates(userID) {
+		if d.Date == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ExclusionService) ensureLoaded(userID int64) error {
+	if s.store.IsExclusionsLoaded(userID) {
+		return nil
+	}
+	s.logger.Debug(fmt.Sprintf("Loading excluded dates into session for userID=%d", userID))
+	if err := s.refresh(userID); err != nil {
+		s.logger.Error(fmt.Sprintf("Error loading excluded dates from DB for userID=%d: %v", userID, err))
+		return err
+	}
+	return nil
+}
+
+func (s *ExclusionService) refresh(userID int64) error {
+	dates, err := s.repo.GetByUser(userID)
+	if err != nil {
+		return err
+	}
+	s.store.SetExcludedDates(userID, dates)
+	return nil
+}
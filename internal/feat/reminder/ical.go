@@ -0,0 +1,431 @@
+package reminder
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrICSNoStart         = errors.New("ics event has no DTSTART")
+	ErrICSAllDay          = errors.New("ics event is all-day")
+	ErrICSUnsupportedRule = errors.New("ics rule unsupported")
+	ErrICSPast            = errors.New("ics event in the past")
+)
+
+const (
+	icsProdID         = "-//safe-box-tg-bot//reminders//RU"
+	icsUTCLayout      = "20060102T150405Z"
+	icsLocalLayout    = "20060102T150405"
+	icsDateLayout     = "20060102"
+	icsMaxLineOctets  = 75
+	icsMonthDayStable = 28 // every month has at least this many days
+)
+
+var icsWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ICSEvent is a VEVENT that maps onto one of the reminder schedules.
+type ICSEvent struct {
+	Summary          string
+	Schedule         models.ReminderSchedule
+	RunAt            time.Time // once only
+	TimeOfDayMinutes int16
+	Weekday          int8
+	MonthDay         int8
+	IntervalMinutes  int32
+}
+
+// ICSSkipped is a VEVENT that could not be turned into a reminder.
+type ICSSkipped struct {
+	Summary string
+	Err     error
+}
+
+// ExportICS renders enabled reminders as an iCalendar feed; DTSTART is the next run in loc.
+func ExportICS(reminders []models.Reminder, now time.Time, loc *time.Location) []byte {
+	if loc == nil {
+		loc = time.UTC
+	}
+	var sb strings.Builder
+	writeICSLine(&sb, "BEGIN:VCALENDAR")
+	writeICSLine(&sb, "VERSION:2.0")
+	writeICSLine(&sb, "PRODID:"+icsProdID)
+	writeICSLine(&sb, "CALSCALE:GREGORIAN")
+	for _, r := range reminders {
+		if !r.Enabled {
+			continue
+		}
+		rrule, ok := icsRRule(r)
+		if !ok {
+			continue
+		}
+		writeICSLine(&sb, "BEGIN:VEVENT")
+		writeICSLine(&sb, fmt.Sprintf("UID:reminder-%d-%d@safeboxtgbot", r.UserID, r.ID))
+		writeICSLine(&sb, "DTSTAMP:"+now.UTC().Format(icsUTCLayout))
+		writeICSLine(&sb, icsDTStart(r.NextRun, loc))
+		if rrule != "" {
+			writeICSLine(&sb, "RRULE:"+rrule)
+		}
+		writeICSLine(&sb, "SUMMARY:"+escapeICSText(r.Name))
+		writeICSLine(&sb, "END:VEVENT")
+	}
+	writeICSLine(&sb, "END:VCALENDAR")
+	return []byte(sb.String())
+}
+
+func icsRRule(r models.Reminder) (string, bool) {
+	switch r.Schedule {
+	case models.ReminderScheduleOnce:
+		return "", true
+	case models.ReminderScheduleInterval:
+		if r.IntervalMinutes == nil {
+			return "", false
+		}
+		return fmt.Sprintf("FREQ=MINUTELY;INTERVAL=%d", *r.IntervalMinutes), true
+	case models.ReminderScheduleDaily:
+		return "FREQ=DAILY", true
+	case models.ReminderScheduleWeekly:
+		if r.Weekday == nil || *r.Weekday < 0 || int(*r.Weekday) >= len(icsWeekdays) {
+			return "", false
+		}
+		return "FREQ=WEEKLY;BYDAY=" + icsWeekdays[*r.Weekday], true
+	case models.ReminderScheduleMonthly:
+		if r.MonthDay == nil {
+			return "", false
+		}
+		day := int(*r.MonthDay)
+		if day <= icsMonthDayStable {
+			return fmt.Sprintf("FREQ=MONTHLY;BYMONTHDAY=%d", day), true
+		}
+		// The scheduler falls back to the last day of shorter months.
+		days := make([]string, 0, day-icsMonthDayStable+1)
+		for d := icsMonthDayStable; d <= day; d++ {
+			days = append(days, strconv.Itoa(d))
+		}
+		return "FREQ=MONTHLY;BYMONTHDAY=" + strings.Join(days, ",") + ";BYSETPOS=-1", true
+	default:
+		return "", false
+	}
+}
+
+func icsDTStart(t time.Time, loc *time.Location) string {
+	if loc == time.UTC {
+		return "DTSTART:" + t.UTC().Format(icsUTCLayout)
+	}
+	return "DTSTART;TZID=" + loc.String() + ":" + t.In(loc).Format(icsLocalLayout)
+}
+
+// writeICSLine folds content lines at 75 octets without splitting UTF-8 sequences.
+func writeICSLine(sb *strings.Builder, line string) {
+	limit := icsMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icsMaxLineOctets - 1
+	}
+	sb.WriteString(line)
+	sb.WriteString("\r\n")
+}
+
+func isUTF8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+func unescapeICSText(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
+
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseICS reads VEVENTs and maps them onto reminder schedules in the user's location.
+// Events without a mapping are returned as skipped with the reason.
+func ParseICS(r io.Reader, now time.Time, loc *time.Location) ([]ICSEvent, []ICSSkipped, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		events  []ICSEvent
+		skipped []ICSSkipped
+		current []icsProperty
+		inEvent bool
+		nested  int
+	)
+	for _, line := range lines {
+		prop, ok := parseICSProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT") && !inEvent:
+			inEvent, nested, current = true, 0, nil
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT") && inEvent && nested == 0:
+			inEvent = false
+			ev, err := icsEventFromProps(current, now, loc)
+			if err != nil {
+				skipped = append(skipped, ICSSkipped{Summary: ev.Summary, Err: err})
+				continue
+			}
+			events = append(events, ev)
+		case !inEvent:
+		case prop.name == "BEGIN":
+			nested++
+		case prop.name == "END":
+			nested--
+		case nested == 0:
+			current = append(current, prop)
+		}
+	}
+	return events, skipped, nil
+}
+
+func unfoldICSLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+func parseICSProperty(line string) (icsProperty, bool) {
+	colon := strings.Index(line, ":")
+	if colon <= 0 {
+		return icsProperty{}, false
+	}
+	head := strings.Split(line[:colon], ";")
+	prop := icsProperty{
+		name:   strings.ToUpper(head[0]),
+		params: make(map[string]string, len(head)-1),
+		value:  line[colon+1:],
+	}
+	for _, p := range head[1:] {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			prop.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return prop, true
+}
+
+func icsEventFromProps(props []icsProperty, now time.Time, loc *time.Location) (ICSEvent, error) {
+	var (
+		ev      ICSEvent
+		dtstart *icsProperty
+		rrule   string
+	)
+	for i := range props {
+		switch props[i].name {
+		case "SUMMARY":
+			ev.Summary = strings.TrimSpace(unescapeICSText(props[i].value))
+		case "DTSTART":
+			dtstart = &props[i]
+		case "RRULE":
+			if rrule != "" {
+				return ev, ErrICSUnsupportedRule
+			}
+			rrule = props[i].value
+		case "RDATE", "EXRULE":
+			return ev, ErrICSUnsupportedRule
+		}
+	}
+	if dtstart == nil {
+		return ev, ErrICSNoStart
+	}
+	start, err := parseICSDateTime(*dtstart, loc)
+	if err != nil {
+		return ev, err
+	}
+	local := start.In(loc)
+	ev.TimeOfDayMinutes = int16(local.Hour()*constants.MinutesInHour + local.Minute())
+
+	if rrule == "" {
+		if !start.After(now) {
+			return ev, ErrICSPast
+		}
+		ev.Schedule = models.ReminderScheduleOnce
+		ev.RunAt = start.UTC()
+		return ev, nil
+	}
+	if err := applyICSRRule(&ev, rrule, start, local); err != nil {
+		return ev, err
+	}
+	return ev, nil
+}
+
+func parseICSDateTime(prop icsProperty, loc *time.Location) (time.Time, error) {
+	value := strings.TrimSpace(prop.value)
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len(icsDateLayout) {
+		return time.Time{}, ErrICSAllDay
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icsUTCLayout, value)
+		if err != nil {
+			return time.Time{}, ErrICSNoStart
+		}
+		return t, nil
+	}
+	eventLoc := loc
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			eventLoc = l
+		}
+	}
+	t, err := time.ParseInLocation(icsLocalLayout, value, eventLoc)
+	if err != nil {
+		return time.Time{}, ErrICSNoStart
+	}
+	return t, nil
+}
+
+// applyICSRRule accepts only the rules ExportICS produces (plus HOURLY intervals):
+// no COUNT/UNTIL, INTERVAL only for sub-daily rules, a single BYDAY/BYMONTHDAY.
+func applyICSRRule(ev *ICSEvent, rrule string, start, local time.Time) error {
+	parts := make(map[string]string)
+	for _, part := range strings.Split(rrule, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return ErrICSUnsupportedRule
+		}
+		parts[strings.ToUpper(k)] = strings.ToUpper(v)
+	}
+	freq := parts["FREQ"]
+	interval := 1
+	if raw, ok := parts["INTERVAL"]; ok {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return ErrICSUnsupportedRule
+		}
+		interval = n
+	}
+	delete(parts, "FREQ")
+	delete(parts, "INTERVAL")
+	delete(parts, "WKST")
+
+	switch freq {
+	case "MINUTELY", "HOURLY":
+		if len(parts) > 0 {
+			return ErrICSUnsupportedRule
+		}
+		minutes := interval
+		if freq == "HOURLY" {
+			minutes *= constants.MinutesInHour
+		}
+		if minutes > constants.MinutesInDay {
+			return ErrICSUnsupportedRule
+		}
+		ev.Schedule = models.ReminderScheduleInterval
+		ev.IntervalMinutes = int32(minutes)
+	case "DAILY":
+		if interval != 1 || len(parts) > 0 {
+			return ErrICSUnsupportedRule
+		}
+		ev.Schedule = models.ReminderScheduleDaily
+	case "WEEKLY":
+		if interval != 1 {
+			return ErrICSUnsupportedRule
+		}
+		weekday := start.Weekday()
+		if raw, ok := parts["BYDAY"]; ok {
+			delete(parts, "BYDAY")
+			idx := indexOf(icsWeekdays, raw)
+			if idx < 0 {
+				return ErrICSUnsupportedRule
+			}
+			weekday = time.Weekday(idx)
+		}
+		if len(parts) > 0 {
+			return ErrICSUnsupportedRule
+		}
+		// Shift the weekday by the day difference the timezone conversion introduced.
+		shift := int(local.Weekday()) - int(start.Weekday())
+		ev.Schedule = models.ReminderScheduleWeekly
+		ev.Weekday = int8((int(weekday) + shift + constants.DaysInWeek) % constants.DaysInWeek)
+	case "MONTHLY":
+		if interval != 1 {
+			return ErrICSUnsupportedRule
+		}
+		day, err := icsMonthDay(parts, local)
+		if err != nil {
+			return err
+		}
+		ev.Schedule = models.ReminderScheduleMonthly
+		ev.MonthDay = int8(day)
+	default:
+		return ErrICSUnsupportedRule
+	}
+	return nil
+}
+
+// icsMonthDay accepts a single positive BYMONTHDAY, or the "28..N;BYSETPOS=-1" form ExportICS
+// uses for days that do not exist in every month.
+func icsMonthDay(parts map[string]string, local time.Time) (int, error) {
+	raw, ok := parts["BYMONTHDAY"]
+	if !ok {
+		if len(parts) > 0 {
+			return 0, ErrICSUnsupportedRule
+		}
+		return local.Day(), nil
+	}
+	setPos, hasSetPos := parts["BYSETPOS"]
+	if len(parts) > 2 || (len(parts) == 2 && !hasSetPos) {
+		return 0, ErrICSUnsupportedRule
+	}
+	days := strings.Split(raw, ",")
+	if hasSetPos {
+		if setPos != "-1" || len(days) < 2 {
+			return 0, ErrICSUnsupportedRule
+		}
+		for i, d := range days {
+			if d != strconv.Itoa(icsMonthDayStable+i) {
+				return 0, ErrICSUnsupportedRule
+			}
+		}
+	} else if len(days) != 1 {
+		return 0, ErrICSUnsupportedRule
+	}
+	day, err := strconv.Atoi(days[len(days)-1])
+	if err != nil || day < 1 || day > 31 {
+		return 0, ErrICSUnsupportedRule
+	}
+	return day, nil
+}
+
+func indexOf(values []string, target string) int {
+	for i, v := range values {
+		if v == target {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,130 @@
+package reminder
+
+import (
+	"bytes"
+	"errors"
+	"safeboxtgbot/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportParseICSRoundTrip(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	now := time.Date(2025, time.January, 1, 10, 0, 0, 0, loc).UTC()
+	timeOfDay := int16(9*60 + 30)
+	weekday := int8(time.Friday)
+	monthDay := int8(31)
+	interval := int32(90)
+	s := NewScheduler()
+
+	reminders := []models.Reminder{
+		{Name: "Daily, with comma", Schedule: models.ReminderScheduleDaily, TimeOfDayMinutes: &timeOfDay, Enabled: true},
+		{Name: "Weekly", Schedule: models.ReminderScheduleWeekly, TimeOfDayMinutes: &timeOfDay, Weekday: &weekday, Enabled: true},
+		{Name: "Monthly", Schedule: models.ReminderScheduleMonthly, TimeOfDayMinutes: &timeOfDay, MonthDay: &monthDay, Enabled: true},
+		{Name: "Interval", Schedule: models.ReminderScheduleInterval, IntervalMinutes: &interval, Enabled: true},
+		{Name: "Once", Schedule: models.ReminderScheduleOnce, NextRun: now.Add(48 * time.Hour), Enabled: true},
+		{Name: "Disabled", Schedule: models.ReminderScheduleDaily, TimeOfDayMinutes: &timeOfDay},
+	}
+	for i := range reminders {
+		if reminders[i].Schedule == models.ReminderScheduleOnce {
+			continue
+		}
+		next, ok := s.ComputeNext(reminders[i], now, loc)
+		if !ok {
+			t.Fatalf("compute next for %q failed", reminders[i].Name)
+		}
+		reminders[i].NextRun = next
+	}
+
+	data := ExportICS(reminders, now, loc)
+	if !bytes.Contains(data, []byte("BYMONTHDAY=28,29,30,31;BYSETPOS=-1")) {
+		t.Fatalf("monthly 31 should fall back to the last day, got:\n%s", data)
+	}
+
+	events, skipped, err := ParseICS(bytes.NewReader(data), now, loc)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(skipped) != 0 {
+		t.Fatalf("unexpected skipped events: %+v", skipped)
+	}
+	if len(events) != 5 {
+		t.Fatalf("events = %d, want 5", len(events))
+	}
+
+	for i, ev := range events {
+		want := reminders[i]
+		if ev.Summary != want.Name || ev.Schedule != want.Schedule {
+			t.Fatalf("event %d = %q/%s, want %q/%s", i, ev.Summary, ev.Schedule, want.Name, want.Schedule)
+		}
+		switch ev.Schedule {
+		case models.ReminderScheduleInterval:
+			if ev.IntervalMinutes != interval {
+				t.Fatalf("interval = %d, want %d", ev.IntervalMinutes, interval)
+			}
+		case models.ReminderScheduleOnce:
+			if !ev.RunAt.Equal(want.NextRun) {
+				t.Fatalf("runAt = %v, want %v", ev.RunAt, want.NextRun)
+			}
+		default:
+			if ev.TimeOfDayMinutes != timeOfDay {
+				t.Fatalf("%s time = %d, want %d", ev.Schedule, ev.TimeOfDayMinutes, timeOfDay)
+			}
+		}
+	}
+	if events[1].Weekday != weekday {
+		t.Fatalf("weekday = %d, want %d", events[1].Weekday, weekday)
+	}
+	if events[2].MonthDay != monthDay {
+		t.Fatalf("month day = %d, want %d", events[2].MonthDay, monthDay)
+	}
+}
+
+func TestParseICSReportsUnsupported(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC)
+	raw := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"SUMMARY:Every other day",
+		"DTSTART:20250102T090000Z",
+		"RRULE:FREQ=DAILY;INTERVAL=2",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:All day",
+		"DTSTART;VALUE=DATE:20250102",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Past",
+		"DTSTART:20241231T090000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Hourly with ",
+		" alarm",
+		"DTSTART;TZID=Europe/Moscow:20250102T090000",
+		"RRULE:FREQ=HOURLY;INTERVAL=3",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT5M",
+		"SUMMARY:ignored",
+		"END:VALARM",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, skipped, err := ParseICS(strings.NewReader(raw), now, time.UTC)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(events) != 1 || events[0].Summary != "Hourly with alarm" || events[0].IntervalMinutes != 180 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	wantErrs := []error{ErrICSUnsupportedRule, ErrICSAllDay, ErrICSPast}
+	if len(skipped) != len(wantErrs) {
+		t.Fatalf("skipped = %+v, want %d entries", skipped, len(wantErrs))
+	}
+	for i, want := range wantErrs {
+		if !errors.Is(skipped[i].Err, want) {
+			t.Fatalf("skipped[%d] = %v, want %v", i, skipped[i].Err, want)
+		}
+	}
+}
@@ -9,16 +9,58 @@ import (
 
 type Scheduler interface {
 	ComputeNext(r models.Reminder, now time.Time, loc *time.Location) (next time.Time, ok bool)
+	IsExcludedNow(r models.Reminder, local time.Time) bool
 }
 
-type DefaultScheduler struct{}
+type DefaultScheduler struct {
+	exclusions ExclusionChecker
+}
 
 func NewScheduler() *DefaultScheduler { return &DefaultScheduler{} }
 
+// WithExclusions makes ComputeNext skip days excluded for the reminder's user.
+func (s *DefaultScheduler) WithExclusions(exclusions ExclusionChecker) *DefaultScheduler {
+	s.exclusions = exclusions
+	return s
+}
+
 func (s DefaultScheduler) ComputeNext(r models.Reminder, now time.Time, loc *time.Location) (next time.Time, ok bool) {
 	if loc == nil {
 		loc = time.UTC
 	}
+	next, ok = s.compute(r, now, loc)
+	if !ok || !s.obeysExclusions(r) {
+		return next, ok
+	}
+
+	for i := 0; i < constants.MaxExclusionSkipDays; i++ {
+		local := next.In(loc)
+		if !s.exclusions.IsExcluded(r.UserID, local) {
+			return next, true
+		}
+		if r.Schedule == models.ReminderScheduleInterval {
+			// Interval reminders resume at the start of the next day.
+			next = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc).UTC()
+			continue
+		}
+		if next, ok = s.compute(r, next, loc); !ok {
+			return time.Time{}, false
+		}
+	}
+	return time.Time{}, false
+}
+
+// IsExcludedNow reports whether a reminder that obeys exclusions must not fire at local.
+func (s DefaultScheduler) IsExcludedNow(r models.Reminder, local time.Time) bool {
+	return s.obeysExclusions(r) && s.exclusions.IsExcluded(r.UserID, local)
+}
+
+// obeysExclusions is false for one-time reminders: their date was picked explicitly.
+func (s DefaultScheduler) obeysExclusions(r models.Reminder) bool {
+	return s.exclusions != nil && !r.AlwaysFire && r.Schedule != models.ReminderScheduleOnce
+}
+
+func (s DefaultScheduler) compute(r models.Reminder, now time.Time, loc *time.Location) (time.Time, bool) {
 	switch r.Schedule {
 	case models.ReminderScheduleInterval:
 		return s.computeInterval(r, now)
@@ -259,3 +259,102 @@ func TestComputeNextDefaultsToUTC(t *testing.T) {
 		t.Fatalf("next = %v, want %v", next, want)
 	}
 }
+
+type fakeExclusions map[string]bool
+
+func (f fakeExclusions) IsExcluded(_ int64, localDay time.Time) bool {
+	return f[localDay.Format(time.DateOnly)]
+}
+
+func TestComputeNextDailySkipsExcludedDays(t *testing.T) {
+	s := NewScheduler().WithExclusions(fakeExclusions{"2025-01-02": true, "2025-01-03": true})
+	timeOfDay := int16(9 * 60)
+	now := time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	next, ok := s.ComputeNext(models.Reminder{
+		Schedule:         models.ReminderScheduleDaily,
+		TimeOfDayMinutes: &timeOfDay,
+	}, now, time.UTC)
+
+	if !ok {
+		t.Fatalf("expected ok for daily schedule")
+	}
+	want := time.Date(2025, time.January, 4, 9, 0, 0, 0, time.UTC)
+	if !next.Equal(want) {
+		t.Fatalf("next = %v, want %v", next, want)
+	}
+}
+
+func TestComputeNextAlwaysFireIgnoresExclusions(t *testing.T) {
+	s := NewScheduler().WithExclusions(fakeExclusions{"2025-01-02": true})
+	timeOfDay := int16(9 * 60)
+	now := time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	next, ok := s.ComputeNext(models.Reminder{
+		Schedule:         models.ReminderScheduleDaily,
+		TimeOfDayMinutes: &timeOfDay,
+		AlwaysFire:       true,
+	}, now, time.UTC)
+
+	if !ok {
+		t.Fatalf("expected ok for daily schedule")
+	}
+	want := time.Date(2025, time.January, 2, 9, 0, 0, 0, time.UTC)
+	if !next.Equal(want) {
+		t.Fatalf("next = %v, want %v", next, want)
+	}
+}
+
+func TestComputeNextIntervalResumesAfterExcludedDay(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	s := NewScheduler().WithExclusions(fakeExclusions{"2025-01-02": true})
+	interval := int32(120)
+	now := time.Date(2025, time.January, 1, 23, 0, 0, 0, loc).UTC()
+
+	next, ok := s.ComputeNext(models.Reminder{
+		Schedule:        models.ReminderScheduleInterval,
+		IntervalMinutes: &interval,
+	}, now, loc)
+
+	if !ok {
+		t.Fatalf("expected ok for interval schedule")
+	}
+	want := time.Date(2025, time.January, 3, 0, 0, 0, 0, loc).UTC()
+	if !next.Equal(want) {
+		t.Fatalf("next = %v, want %v", next, want)
+	}
+}
+
+func TestComputeNextWeeklyAllExcludedFails(t *testing.T) {
+	s := NewScheduler().WithExclusions(allExcluded{})
+	timeOfDay := int16(8 * 60)
+	weekday := int8(time.Monday)
+
+	_, ok := s.ComputeNext(models.Reminder{
+		Schedule:         models.ReminderScheduleWeekly,
+		TimeOfDayMinutes: &timeOfDay,
+		Weekday:          &weekday,
+	}, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), time.UTC)
+
+	if ok {
+		t.Fatalf("expected ok=false when every day is excluded")
+	}
+}
+
+func TestComputeNextOnceIgnoresExclusions(t *testing.T) {
+	s := NewScheduler().WithExclusions(allExcluded{})
+	runAt := time.Date(2025, time.January, 2, 9, 0, 0, 0, time.UTC)
+
+	next, ok := s.ComputeNext(models.Reminder{
+		Schedule: models.ReminderScheduleOnce,
+		NextRun:  runAt,
+	}, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), time.UTC)
+
+	if !ok || !next.Equal(runAt) {
+		t.Fatalf("expected once schedule to keep next=%v, got next=%v ok=%v", runAt, next, ok)
+	}
+}
+
+type allExcluded struct{}
+
+func (allExcluded) IsExcluded(int64, time.Time) bool { return true }
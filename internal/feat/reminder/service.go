@@ -246,6 +246,24 @@ func (s *Service) CreateOnce(userID int64, entityName string, runAt time.Time, n
 	return &r, nil
 }
 
+// ImportEvent creates a reminder from a parsed iCalendar event.
+func (s *Service) ImportEvent(userID int64, ev ICSEvent, now time.Time, loc *time.Location) (*models.Reminder, error) {
+	switch ev.Schedule {
+	case models.ReminderScheduleInterval:
+		return s.CreateInterval(userID, ev.Summary, ev.IntervalMinutes, now, loc)
+	case models.ReminderScheduleDaily:
+		return s.CreateDaily(userID, ev.Summary, ev.TimeOfDayMinutes, now, loc)
+	case models.ReminderScheduleWeekly:
+		return s.CreateWeekly(userID, ev.Summary, ev.Weekday, ev.TimeOfDayMinutes, now, loc)
+	case models.ReminderScheduleMonthly:
+		return s.CreateMonthly(userID, ev.Summary, ev.MonthDay, ev.TimeOfDayMinutes, now, loc)
+	case models.ReminderScheduleOnce:
+		return s.CreateOnce(userID, ev.Summary, ev.RunAt, now, loc)
+	default:
+		return nil, ErrInvalidSchedule
+	}
+}
+
 func (s *Service) Enable(id uint, userID int64, now time.Time, loc *time.Location) error {
 	if err := s.ensureRemindersSessionLoaded(userID); err != nil {
 		return err
@@ -346,6 +364,62 @@ func (s *Service) Reschedule(r *models.Reminder, now time.Time, loc *time.Locati
 	return nil
 }
 
+// SetAlwaysFire toggles whether the reminder ignores exclusion dates and recomputes its next run.
+func (s *Service) SetAlwaysFire(id uint, userID int64, alwaysFire bool, now time.Time, loc *time.Location) error {
+	if err := s.ensureRemindersSessionLoaded(userID); err != nil {
+		return err
+	}
+	r, found, err := s.reminderRepo.TryGet(id)
+	if err != nil {
+		return err
+	}
+	if !found || r.UserID != userID {
+		return ErrReminderNotFound
+	}
+	r.AlwaysFire = alwaysFire
+	if r.Enabled && r.Schedule != models.ReminderScheduleOnce && r.Schedule != models.ReminderScheduleInterval {
+		if next, ok := s.scheduler.ComputeNext(*r, now, loc); ok {
+			r.NextRun = next
+		}
+	}
+	if err := s.reminderRepo.SetAlwaysFire(id, userID, alwaysFire, r.NextRun); err != nil {
+		return err
+	}
+	s.upsertReminderInStore(userID, *r)
+	return nil
+}
+
+// RefreshSchedules recomputes next runs of recurring reminders, e.g. after exclusion dates change.
+// Interval reminders keep their next run: recomputing would restart the interval from now, and
+// the worker skips an excluded run when it fires.
+func (s *Service) RefreshSchedules(userID int64, now time.Time, loc *time.Location) error {
+	if err := s.ensureRemindersSessionLoaded(userID); err != nil {
+		return err
+	}
+	reminders := append([]models.Reminder(nil), s.store.GetReminderList(userID)...)
+	for i := range reminders {
+		r := &reminders[i]
+		if !r.Enabled || r.Schedule == models.ReminderScheduleOnce || r.Schedule == models.ReminderScheduleInterval {
+			continue
+		}
+		next, ok := s.scheduler.ComputeNext(*r, now, loc)
+		if !ok || next.Equal(r.NextRun) {
+			continue
+		}
+		if err := s.reminderRepo.UpdateNextRunAt(r.ID, userID, next); err != nil {
+			return err
+		}
+		r.NextRun = next
+	}
+	s.store.SetReminderList(userID, reminders)
+	return nil
+}
+
+// IsExcludedNow reports whether the reminder must be skipped at the given local time.
+func (s *Service) IsExcludedNow(r models.Reminder, local time.Time) bool {
+	return s.scheduler.IsExcludedNow(r, local)
+}
+
 func (s *Service) SetNextRun(id uint, userID int64, next time.Time) error {
 	if err := s.ensureRemindersSessionLoaded(userID); err != nil {
 		return err
@@ -0,0 +1,51 @@
+package reminder
+
+import (
+	"errors"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrUndoExpired = errors.New("undo window expired")
+
+// Restore brings back a reminder deleted less than constants.DeletionUndoWindow ago.
+// A recurring reminder whose next run passed meanwhile is rescheduled instead of firing late.
+func (s *Service) Restore(id uint, userID int64, now time.Time, loc *time.Location) (*models.Reminder, error) {
+	if err := s.ensureRemindersSessionLoaded(userID); err != nil {
+		return nil, err
+	}
+	r, found, err := s.reminderRepo.TryGetDeleted(id, userID, now.Add(-constants.DeletionUndoWindow))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrUndoExpired
+	}
+	if s.isDuplicateName(userID, r.Name) {
+		return nil, ErrReminderDuplicate
+	}
+
+	if r.Enabled && r.Schedule != models.ReminderScheduleOnce && r.NextRun.Before(now) {
+		if next, ok := s.scheduler.ComputeNext(*r, now, loc); ok {
+			r.NextRun = next
+		}
+	}
+	restored, err := s.reminderRepo.Restore(id, userID, r.NextRun)
+	if err != nil {
+		return nil, err
+	}
+	if !restored {
+		return nil, ErrUndoExpired
+	}
+	r.DeletedAt = gorm.DeletedAt{}
+	s.upsertReminderInStore(userID, *r)
+	return r, nil
+}
+
+// PurgeDeleted permanently removes reminders whose undo window closed before now.
+func (s *Service) PurgeDeleted(now time.Time) (int64, error) {
+	return s.reminderRepo.PurgeDeleted(now.Add(-constants.DeletionUndoWindow))
+}
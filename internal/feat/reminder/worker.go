@@ -90,9 +90,18 @@ func (w *Worker) handle(nowUTC time.Time, r models.Reminder) {
 		return
 	}
 
-	text := helpers.FallbackText(r.Name, constants.FallbackEmojis)
+	if w.reminderService.IsExcludedNow(r, localNow) {
+		w.logger.Debug(fmt.Sprintf("reminder %d skipped: excluded date %s", r.ID, localNow.Format(time.DateOnly)))
+		if err := w.reminderService.Reschedule(&r, nowUTC, loc); err != nil {
+			w.logger.Error(fmt.Sprintf("reschedule reminder %d: %v", r.ID, err))
+		}
+		return
+	}
+
+	mode := helpers.ModeAt(*userDTO, localNow)
+	text := helpers.FallbackText(r.Name, helpers.ModeFallbackEmojis(mode))
 	if w.messageGenerator != nil {
-		if generated, err := w.generateText(localNow, *userDTO, r.Name); err == nil && generated != "" {
+		if generated, err := w.generateText(userDTO.TelegramID, localNow, mode, r.Name); err == nil && generated != "" {
 			text = generated
 		}
 	}
@@ -125,14 +134,16 @@ func (w *Worker) send(userID int64, text string) error {
 	return err
 }
 
-func (w *Worker) generateText(localNow time.Time, user models.User, entityName string) (string, error) {
+func (w *Worker) generateText(userID int64, localNow time.Time, mode models.UserMode, entityName string) (string, error) {
 	input := prompt.LLMInput{
 		CurrentEntity: entityName,
 		TimeOfDay:     helpers.TimeOfDay(localNow),
-		StyleMode:     helpers.ModeToStyle(user.Mode),
+		StyleMode:     helpers.ModeToStyle(mode),
+		StylePrompt:   helpers.ModeStylePrompt(mode),
 		RandomSeed:    utils.RandomIntRange(1, 1_000_000),
+		Reminder:      true,
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Second)
+	ctx, cancel := context.WithTimeout(prompt.WithUserID(context.Background(), userID), 500*time.Second)
 	defer cancel()
 	return w.messageGenerator.Generate(ctx, input)
 }
@@ -0,0 +1,70 @@
+package tags
+
+import (
+	"safeboxtgbot/models"
+)
+
+// EligibleItems drops items whose tag is disabled or whose tag slot does not cover localMinutes.
+// Items with an unknown tag are kept, so a stale reference never silences an item.
+func EligibleItems(itemList []models.Item, tagList []models.Tag, localMinutes int) []models.Item {
+	byID := make(map[uint]models.Tag, len(tagList))
+	for _, tag := range tagList {
+		byID[tag.ID] = tag
+	}
+
+	eligible := make([]models.Item, 0, len(itemList))
+	for _, item := range itemList {
+		if item.TagID != nil {
+			if tag, ok := byID[*item.TagID]; ok && !IsActiveAt(tag, localMinutes) {
+				continue
+			}
+		}
+		eligible = append(eligible, item)
+	}
+	return eligible
+}
+
+// IsActiveAt reports whether the tag is enabled and its slot covers localMinutes.
+func IsActiveAt(tag models.Tag, localMinutes int) bool {
+	if tag.Disabled {
+		return false
+	}
+	if tag.SlotStartMinutes == nil || tag.SlotEndMinutes == nil {
+		return true
+	}
+	start, end := int(*tag.SlotStartMinutes), int(*tag.SlotEndMinutes)
+	if start <= end {
+		return localMinutes >= start && localMinutes < end
+	}
+	return localMinutes >= start || localMinutes < end
+}
+
+// GroupByTag splits items by tag in tag list order; untagged items and items of
+// unknown tags go last under the zero key.
+func GroupByTag(itemList []models.Item, tagList []models.Tag) ([]uint, map[uint][]models.Item) {
+	known := make(map[uint]struct{}, len(tagList))
+	for _, tag := range tagList {
+		known[tag.ID] = struct{}{}
+	}
+	groups := make(map[uint][]models.Item)
+	for _, item := range itemList {
+		var key uint
+		if item.TagID != nil {
+			if _, ok := known[*item.TagID]; ok {
+				key = *item.TagID
+			}
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	order := make([]uint, 0, len(groups))
+	for _, tag := range tagList {
+		if _, ok := groups[tag.ID]; ok {
+			order = append(order, tag.ID)
+		}
+	}
+	if _, ok := groups[0]; ok {
+		order = append(order, 0)
+	}
+	return order, groups
+}
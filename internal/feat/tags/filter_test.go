@@ -0,0 +1,77 @@
+package tags
+
+import (
+	"safeboxtgbot/models"
+	"testing"
+)
+
+func tagWithSlot(id uint, start, end int16) models.Tag {
+	tag := models.Tag{SlotStartMinutes: &start, SlotEndMinutes: &end}
+	tag.ID = id
+	return tag
+}
+
+func TestEligibleItems(t *testing.T) {
+	food, work, night, off := uint(1), uint(2), uint(3), uint(4)
+	unknown := uint(99)
+	disabled := models.Tag{Disabled: true}
+	disabled.ID = off
+	tagList := []models.Tag{
+		{Name: "food"},
+		tagWithSlot(work, 9*60, 18*60),
+		tagWithSlot(night, 22*60, 6*60),
+		disabled,
+	}
+	tagList[0].ID = food
+	itemList := []models.Item{
+		{Name: "untagged"},
+		{Name: "food", TagID: &food},
+		{Name: "work", TagID: &work},
+		{Name: "night", TagID: &night},
+		{Name: "off", TagID: &off},
+		{Name: "stale", TagID: &unknown},
+	}
+
+	cases := []struct {
+		minutes int
+		want    []string
+	}{
+		{minutes: 12 * 60, want: []string{"untagged", "food", "work", "stale"}},
+		{minutes: 18 * 60, want: []string{"untagged", "food", "stale"}},
+		{minutes: 23 * 60, want: []string{"untagged", "food", "night", "stale"}},
+		{minutes: 5*60 + 59, want: []string{"untagged", "food", "night", "stale"}},
+	}
+	for _, tc := range cases {
+		got := EligibleItems(itemList, tagList, tc.minutes)
+		if len(got) != len(tc.want) {
+			t.Fatalf("minutes=%d: got %d items %+v, want %v", tc.minutes, len(got), got, tc.want)
+		}
+		for i, name := range tc.want {
+			if got[i].Name != name {
+				t.Fatalf("minutes=%d: item %d = %q, want %q", tc.minutes, i, got[i].Name, name)
+			}
+		}
+	}
+}
+
+func TestGroupByTag(t *testing.T) {
+	a, b, gone := uint(1), uint(2), uint(7)
+	tagA := models.Tag{Name: "a"}
+	tagA.ID = a
+	tagB := models.Tag{Name: "b"}
+	tagB.ID = b
+	itemList := []models.Item{
+		{Name: "x", TagID: &b},
+		{Name: "y"},
+		{Name: "z", TagID: &gone},
+		{Name: "w", TagID: &b},
+	}
+
+	order, groups := GroupByTag(itemList, []models.Tag{tagA, tagB})
+	if len(order) != 2 || order[0] != b || order[1] != 0 {
+		t.Fatalf("unexpected group order: %v", order)
+	}
+	if len(groups[b]) != 2 || len(groups[0]) != 2 {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+}
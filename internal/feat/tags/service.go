@@ -0,0 +1,246 @@
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/core/logger"
+	"safeboxtgbot/internal/feat/items"
+	"safeboxtgbot/internal/repo"
+	"safeboxtgbot/internal/session"
+	"safeboxtgbot/models"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrTagNameEmpty    = errors.New("tag name empty")
+	ErrTagNameTooLong  = errors.New("tag name too long")
+	ErrTagDuplicate    = errors.New("tag duplicate")
+	ErrTagLimitReached = errors.New("tag limit reached")
+	ErrTagNotFound     = errors.New("tag not found")
+	ErrTagSlotInvalid  = errors.New("tag slot invalid")
+)
+
+// Service manages item categories. Tag changes that touch items refresh the items session too.
+type Service struct {
+	repo         *repo.TagRepo
+	itemRepo     *repo.ItemRepo
+	itemsService *items.Service
+	store        *session.Store
+	logger       logger.AppLogger
+}
+
+func NewService(
+	repo *repo.TagRepo,
+	itemRepo *repo.ItemRepo,
+	itemsService *items.Service,
+	store *session.Store,
+	logger logger.AppLogger,
+) *Service {
+	return &Service{repo: repo, itemRepo: itemRepo, itemsService: itemsService, store: store, logger: logger}
+}
+
+func (s *Service) GetList(userID int64) ([]models.Tag, error) {
+	if err := s.ensureLoaded(userID); err != nil {
+		return nil, err
+	}
+	return s.store.GetTagList(userID), nil
+}
+
+func (s *Service) GetByID(userID int64, tagID uint) (*models.Tag, error) {
+	list, err := s.GetList(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		if list[i].ID == tagID {
+			tag := list[i]
+			return &tag, nil
+		}
+	}
+	return nil, ErrTagNotFound
+}
+
+func (s *Service) Create(userID int64, rawName string) error {
+	if err := s.ensureLoaded(userID); err != nil {
+		return err
+	}
+	name, err := normalizeTagName(rawName)
+	if err != nil {
+		return err
+	}
+
+	list := s.store.GetTagList(userID)
+	if len(list) >= constants.MaxTagsPerUser {
+		return ErrTagLimitReached
+	}
+	for _, tag := range list {
+		if tag.Name == name {
+			return ErrTagDuplicate
+		}
+	}
+
+	if err := s.repo.Create(&models.Tag{UserID: userID, Name: name}); err != nil {
+		return err
+	}
+	return s.refresh(userID)
+}
+
+func (s *Service) SetDisabled(userID int64, tagID uint, disabled bool) error {
+	if _, err := s.GetByID(userID, tagID); err != nil {
+		return err
+	}
+	updated, err := s.repo.UpdateDisabled(userID, tagID, disabled)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrTagNotFound
+	}
+	return s.refresh(userID)
+}
+
+// SetSlot limits the tag to [startMinutes, endMinutes) local time; nil bounds clear the slot.
+// A slot whose end is before its start wraps past midnight.
+func (s *Service) SetSlot(userID int64, tagID uint, startMinutes, endMinutes *int16) error {
+	if (startMinutes == nil) != (endMinutes == nil) {
+		return ErrTagSlotInvalid
+	}
+	if startMinutes != nil && (!validMinutes(*startMinutes) || !validMinutes(*endMinutes) || *startMinutes == *endMinutes) {
+		return ErrTagSlotInvalid
+	}
+	if _, err := s.GetByID(userID, tagID); err != nil {
+		return err
+	}
+	updated, err := s.repo.UpdateSlot(userID, tagID, startMinutes, endMinutes)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrTagNotFound
+	}
+	return s.refresh(userID)
+}
+
+// Delete removes the tag; its items stay and become untagged.
+func (s *Service) Delete(userID int64, tagID uint) error {
+	if _, err := s.GetByID(userID, tagID); err != nil {
+		return err
+	}
+	deleted, err := s.repo.Delete(userID, tagID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrTagNotFound
+	}
+	if err := s.refresh(userID); err != nil {
+		return err
+	}
+	return s.itemsService.Reload(userID)
+}
+
+// ToggleItem puts the item into the tag, or takes it out if it is already there.
+func (s *Service) ToggleItem(userID int64, tagID uint, itemID uint) error {
+	if _, err := s.GetByID(userID, tagID); err != nil {
+		return err
+	}
+	item, err := s.itemsService.GetItemByID(userID, itemID)
+	if err != nil {
+		return err
+	}
+
+	var target *uint
+	if item.TagID == nil || *item.TagID != tagID {
+		target = &tagID
+	}
+	updated, err := s.itemRepo.UpdateTag(userID, itemID, target)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return items.ErrItemNotFound
+	}
+	return s.itemsService.Reload(userID)
+}
+
+// EligibleItems returns the items the notifier may pick at localMinutes: untagged items
+// and items of enabled tags whose slot, if any, covers the time.
+func (s *Service) EligibleItems(userID int64, itemList []models.Item, localMinutes int) ([]models.Item, error) {
+	list, err := s.GetList(userID)
+	if err != nil {
+		return nil, err
+	}
+	return EligibleItems(itemList, list, localMinutes), nil
+}
+
+func (s *Service) GetSelectedID(userID int64) uint {
+	return s.store.GetSelectedTagID(userID)
+}
+
+func (s *Service) SetSelectedID(userID int64, tagID uint) {
+	s.store.SetSelectedTagID(userID, tagID)
+}
+
+// GetSlotStart returns the start hour picked in the slot picker, or -1.
+func (s *Service) GetSlotStart(userID int64) int {
+	return s.store.GetTagSlotStart(userID)
+}
+
+func (s *Service) SetSlotStart(userID int64, hour int) {
+	s.store.SetTagSlotStart(userID, hour)
+}
+
+// GetFilter returns the tag the item box is narrowed to; 0 shows every item.
+func (s *Service) GetFilter(userID int64) uint {
+	return s.store.GetItemsTagFilter(userID)
+}
+
+func (s *Service) SetFilter(userID int64, tagID uint) {
+	s.store.SetItemsTagFilter(userID, tagID)
+}
+
+func (s *Service) IsGrouped(userID int64) bool {
+	return s.store.IsItemsGroupedByTag(userID)
+}
+
+func (s *Service) SetGrouped(userID int64, grouped bool) {
+	s.store.SetItemsGroupedByTag(userID, grouped)
+}
+
+func (s *Service) ensureLoaded(userID int64) error {
+	if s.store.IsTagsLoaded(userID) {
+		return nil
+	}
+	s.logger.Debug(fmt.Sprintf("Loading tags into session for userID=%d", userID))
+	if err := s.refresh(userID); err != nil {
+		s.logger.Error(fmt.Sprintf("Error loading tags from DB for userID=%d: %v", userID, err))
+		return err
+	}
+	return nil
+}
+
+func (s *Service) refresh(userID int64) error {
+	list, err := s.repo.GetByUser(userID)
+	if err != nil {
+		return err
+	}
+	s.store.SetTagList(userID, list)
+	return nil
+}
+
+func normalizeTagName(raw string) (string, error) {
+	name := strings.ToLower(strings.Join(strings.Fields(raw), " "))
+	if name == "" {
+		return "", ErrTagNameEmpty
+	}
+	if utf8.RuneCountInString(name) > constants.MaxTagNameLen {
+		return "", ErrTagNameTooLong
+	}
+	return name, nil
+}
+
+func validMinutes(m int16) bool {
+	return m >= 0 && m < 24*60
+}
@@ -79,6 +79,23 @@ func (s *Service) UpdateMode(userID int64, mode models.UserMode) error {
 	return s.userRepo.UpdateMode(userID, mode)
 }
 
+// UpdateAutoModeMap stores the AUTO_MODE time-of-day styles, see modes.FormatAutoMap.
+func (s *Service) UpdateAutoModeMap(userID int64, autoMap string) error {
+	s.ensureUserSessionLoaded(userID)
+	s.store.Update(userID, func(sess *session.Session) {
+		sess.User.AutoModeMap = autoMap
+	})
+	return s.userRepo.UpdateAutoModeMap(userID, autoMap)
+}
+
+func (s *Service) UpdateHolidayCalendar(userID int64, code string) error {
+	s.ensureUserSessionLoaded(userID)
+	s.store.Update(userID, func(sess *session.Session) {
+		sess.User.HolidayCalendar = code
+	})
+	return s.userRepo.UpdateHolidayCalendar(userID, code)
+}
+
 func (s *Service) UpdateNextNotification(userID int64, t time.Time) error {
 	s.ensureUserSessionLoaded(userID)
 	s.store.Update(userID, func(sess *session.Session) {
@@ -18,6 +18,19 @@ const (
 	StateItemEditSelectOpened   = "item_edit_select_opened"
 	StateAwaitingItemEdit       = "awaiting_item_edit"
 	StateItemDeleteSelectOpened = "item_delete_select_opened"
+	StateItemPauseSelectOpened  = "item_pause_select_opened"
+	StateItemNoteSelectOpened   = "item_note_select_opened"
+	StateAwaitingItemNote       = "awaiting_item_note"
+	StateItemStatsSelectOpened  = "item_stats_select_opened"
+	StatePhraseSelectOpened     = "phrase_select_opened"
+	StateAwaitingPhraseAdd      = "awaiting_phrase_add"
+	StateTagsMenuOpened         = "tags_menu_opened"
+	StateAwaitingTagAdd         = "awaiting_tag_add"
+	StateTagAssignOpened        = "tag_assign_opened"
+	StateSharedBoxesOpened      = "shared_boxes_opened"
+	StateAwaitingSharedBoxName  = "awaiting_shared_box_name"
+	StateAwaitingSharedBoxCode  = "awaiting_shared_box_code"
+	StateAwaitingSharedItemAdd  = "awaiting_shared_item_add"
 	StateRemindersMenuOpened    = "reminders_menu_opened"
 	StateAwaitingReminderAdd    = "awaiting_reminder_add"
 	StateReminderDeleteSelect   = "reminder_delete_select"
@@ -31,6 +44,19 @@ const (
 	ItemEditSelectOpenedEvent   = "item_edit_select_opened__event"
 	AwaitingItemEditEvent       = "awaiting_item_edit__event"
 	ItemDeleteSelectOpenedEvent = "item_delete_select_opened__event"
+	ItemPauseSelectOpenedEvent  = "item_pause_select_opened__event"
+	ItemNoteSelectOpenedEvent   = "item_note_select_opened__event"
+	AwaitingItemNoteEvent       = "awaiting_item_note__event"
+	ItemStatsSelectOpenedEvent  = "item_stats_select_opened__event"
+	PhraseSelectOpenedEvent     = "phrase_select_opened__event"
+	AwaitingPhraseAddEvent      = "awaiting_phrase_add__event"
+	TagsMenuOpenedEvent         = "tags_menu_opened__event"
+	AwaitingTagAddEvent         = "awaiting_tag_add__event"
+	TagAssignOpenedEvent        = "tag_assign_opened__event"
+	SharedBoxesOpenedEvent      = "shared_boxes_opened__event"
+	AwaitingSharedBoxNameEvent  = "awaiting_shared_box_name__event"
+	AwaitingSharedBoxCodeEvent  = "awaiting_shared_box_code__event"
+	AwaitingSharedItemAddEvent  = "awaiting_shared_item_add__event"
 	RemindersMenuOpenedEvent    = "reminders_menu_opened__event"
 	AwaitingReminderAddEvent    = "awaiting_reminder_add__event"
 	ReminderDeleteSelectEvent   = "reminder_delete_select__event"
@@ -47,6 +73,19 @@ var events = []f.EventDesc{
 			StateItemEditSelectOpened,
 			StateAwaitingItemEdit,
 			StateItemDeleteSelectOpened,
+			StateItemPauseSelectOpened,
+			StateItemNoteSelectOpened,
+			StateAwaitingItemNote,
+			StateItemStatsSelectOpened,
+			StatePhraseSelectOpened,
+			StateAwaitingPhraseAdd,
+			StateTagsMenuOpened,
+			StateAwaitingTagAdd,
+			StateTagAssignOpened,
+			StateSharedBoxesOpened,
+			StateAwaitingSharedBoxName,
+			StateAwaitingSharedBoxCode,
+			StateAwaitingSharedItemAdd,
 			StateRemindersMenuOpened,
 			StateAwaitingReminderAdd,
 			StateReminderDeleteSelect,
@@ -63,6 +102,19 @@ var events = []f.EventDesc{
 			StateItemEditSelectOpened,
 			StateAwaitingItemEdit,
 			StateItemDeleteSelectOpened,
+			StateItemPauseSelectOpened,
+			StateItemNoteSelectOpened,
+			StateAwaitingItemNote,
+			StateItemStatsSelectOpened,
+			StatePhraseSelectOpened,
+			StateAwaitingPhraseAdd,
+			StateTagsMenuOpened,
+			StateAwaitingTagAdd,
+			StateTagAssignOpened,
+			StateSharedBoxesOpened,
+			StateAwaitingSharedBoxName,
+			StateAwaitingSharedBoxCode,
+			StateAwaitingSharedItemAdd,
 			StateRemindersMenuOpened,
 			StateAwaitingReminderAdd,
 			StateReminderDeleteSelect,
@@ -73,6 +125,30 @@ var events = []f.EventDesc{
 	{Name: ItemEditSelectOpenedEvent, Src: []string{StateInitial, StateItemsMenuOpened}, Dst: StateItemEditSelectOpened},
 	{Name: AwaitingItemEditEvent, Src: []string{StateInitial, StateItemEditSelectOpened}, Dst: StateAwaitingItemEdit},
 	{Name: ItemDeleteSelectOpenedEvent, Src: []string{StateInitial, StateItemsMenuOpened}, Dst: StateItemDeleteSelectOpened},
+	{Name: ItemPauseSelectOpenedEvent, Src: []string{StateInitial, StateItemsMenuOpened}, Dst: StateItemPauseSelectOpened},
+	{Name: ItemNoteSelectOpenedEvent, Src: []string{StateInitial, StateItemsMenuOpened, StateAwaitingItemNote}, Dst: StateItemNoteSelectOpened},
+	{Name: AwaitingItemNoteEvent, Src: []string{StateItemNoteSelectOpened}, Dst: StateAwaitingItemNote},
+	{Name: ItemStatsSelectOpenedEvent, Src: []string{StateInitial, StateItemsMenuOpened}, Dst: StateItemStatsSelectOpened},
+	{Name: PhraseSelectOpenedEvent, Src: []string{StateInitial, StateItemsMenuOpened, StateAwaitingPhraseAdd}, Dst: StatePhraseSelectOpened},
+	{Name: AwaitingPhraseAddEvent, Src: []string{StatePhraseSelectOpened}, Dst: StateAwaitingPhraseAdd},
+	{Name: TagsMenuOpenedEvent, Src: []string{StateInitial, StateItemsMenuOpened, StateTagsMenuOpened, StateAwaitingTagAdd, StateTagAssignOpened}, Dst: StateTagsMenuOpened},
+	{Name: AwaitingTagAddEvent, Src: []string{StateTagsMenuOpened}, Dst: StateAwaitingTagAdd},
+	{Name: TagAssignOpenedEvent, Src: []string{StateTagsMenuOpened}, Dst: StateTagAssignOpened},
+	{
+		Name: SharedBoxesOpenedEvent,
+		Src: []string{
+			StateInitial,
+			StateItemsMenuOpened,
+			StateSharedBoxesOpened,
+			StateAwaitingSharedBoxName,
+			StateAwaitingSharedBoxCode,
+			StateAwaitingSharedItemAdd,
+		},
+		Dst: StateSharedBoxesOpened,
+	},
+	{Name: AwaitingSharedBoxNameEvent, Src: []string{StateSharedBoxesOpened}, Dst: StateAwaitingSharedBoxName},
+	{Name: AwaitingSharedBoxCodeEvent, Src: []string{StateSharedBoxesOpened}, Dst: StateAwaitingSharedBoxCode},
+	{Name: AwaitingSharedItemAddEvent, Src: []string{StateSharedBoxesOpened}, Dst: StateAwaitingSharedItemAdd},
 	{Name: RemindersMenuOpenedEvent, Src: []string{StateInitial, StateItemsMenuOpened, StateReminderDeleteSelect, StateAwaitingReminderAdd}, Dst: StateRemindersMenuOpened},
 	{Name: AwaitingReminderAddEvent, Src: []string{StateRemindersMenuOpened, StateReminderDeleteSelect}, Dst: StateAwaitingReminderAdd},
 	{Name: ReminderDeleteSelectEvent, Src: []string{StateRemindersMenuOpened}, Dst: StateReminderDeleteSelect},
@@ -2,10 +2,14 @@ package commands
 
 import (
 	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/config"
 	"safeboxtgbot/internal/feat/prompt"
 	adminMiddleware "safeboxtgbot/internal/middleware/admin"
+	"safeboxtgbot/internal/repo"
 )
 
-func MustInitAdminCommandsHandler(bot *b.Bot, messageGenerator prompt.MessageGenerator, builder prompt.PromptBuilder, fallback prompt.LLMGenerator, forcePreviewFallback bool) {
+func MustInitAdminCommandsHandler(bot *b.Bot, messageGenerator prompt.MessageGenerator, builder prompt.PromptBuilder, fallback prompt.LLMGenerator, forcePreviewFallback bool, health prompt.HealthReporter, usageRepo *repo.LLMCallRepo, prices config.LLMPrices) {
 	bot.Handle("/preview_llm", createPreviewLLMHandler(bot, messageGenerator, builder, fallback, forcePreviewFallback), adminMiddleware.CreateAdminMiddleware(bot))
+	bot.Handle("/llm_health", createLLMHealthHandler(bot, health), adminMiddleware.CreateAdminMiddleware(bot))
+	bot.Handle("/llm_usage", createLLMUsageHandler(bot, usageRepo, prices), adminMiddleware.CreateAdminMiddleware(bot))
 }
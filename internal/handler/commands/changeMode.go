@@ -4,26 +4,32 @@ import (
 	"fmt"
 	b "safeboxtgbot/internal"
 	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/core/modes"
 	"safeboxtgbot/internal/helpers"
 	"safeboxtgbot/internal/middleware/auth"
 	"safeboxtgbot/models"
+	"strings"
 	"time"
 
 	"gopkg.in/telebot.v4"
 )
 
 var (
-	btnModeRofl  = telebot.Btn{Unique: "btn_mode_rofl", Text: "😜 Рофл"}
-	btnModeCozy  = telebot.Btn{Unique: "btn_mode_cozy", Text: "🏡 Уют"}
-	btnModeCare  = telebot.Btn{Unique: "btn_mode_care", Text: "🤍 Забота"}
-	btnModeClose = telebot.Btn{Unique: "btn_mode_close", Text: "✖️ Закрыть"}
+	btnModeSelect    = telebot.Btn{Unique: "btn_mode_select"}
+	btnAutoModeCycle = telebot.Btn{Unique: "btn_auto_mode_cycle"}
+	btnModeClose     = telebot.Btn{Unique: "btn_mode_close", Text: "✖️ Закрыть"}
+
+	// legacyModeButtons keep /change_mode messages sent before the mode registry working.
+	legacyModeButtons = []telebot.Btn{{Unique: "btn_mode_rofl"}, {Unique: "btn_mode_cozy"}, {Unique: "btn_mode_care"}}
 )
 
 func initChangeModeHandler(bot *b.Bot) {
 	bot.Handle("/change_mode", createChangeModeHandler(bot), auth.CreateAuthMiddleware(bot))
-	bot.Handle(&btnModeRofl, createModeSelectHandler(bot), auth.CreateAuthMiddleware(bot))
-	bot.Handle(&btnModeCozy, createModeSelectHandler(bot), auth.CreateAuthMiddleware(bot))
-	bot.Handle(&btnModeCare, createModeSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnModeSelect, createModeSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+	for i := range legacyModeButtons {
+		bot.Handle(&legacyModeButtons[i], createModeSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+	}
+	bot.Handle(&btnAutoModeCycle, createAutoModeCycleHandler(bot), auth.CreateAuthMiddleware(bot))
 	bot.Handle(&btnModeClose, createCloseModeHandler(bot), auth.CreateAuthMiddleware(bot))
 }
 
@@ -65,6 +71,15 @@ func createModeSelectHandler(bot *b.Bot) telebot.HandlerFunc {
 			return ctx.Respond(&telebot.CallbackResponse{Text: bot.Replies.Error, ShowAlert: true})
 		}
 
+		if mode == constants.AutoMode {
+			autoMap := modes.ParseAutoMap(bot.UserService.GetUser(userID).AutoModeMap)
+			if err := bot.UserService.UpdateAutoModeMap(userID, modes.FormatAutoMap(autoMap)); err != nil {
+				bot.Logger.Error(fmt.Sprintf("Error updating auto mode map for userID=%d: %v", userID, err))
+			}
+			_ = ctx.Respond()
+			return ctx.Edit(autoModeText(bot, autoMap), autoModeMarkup(bot, autoMap))
+		}
+
 		if ctx.Message() != nil {
 			bot.MustDelete(ctx.Message())
 		}
@@ -81,6 +96,26 @@ func createModeSelectHandler(bot *b.Bot) telebot.HandlerFunc {
 	}
 }
 
+// createAutoModeCycleHandler switches the style of one time-of-day bucket to the next mode.
+func createAutoModeCycleHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bucket := ctx.Data()
+		autoMap := modes.ParseAutoMap(bot.UserService.GetUser(userID).AutoModeMap)
+		current, ok := autoMap[bucket]
+		if !ok {
+			return ctx.Respond(&telebot.CallbackResponse{Text: bot.Replies.Error, ShowAlert: true})
+		}
+		autoMap[bucket] = modes.Next(current)
+		if err := bot.UserService.UpdateAutoModeMap(userID, modes.FormatAutoMap(autoMap)); err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error updating auto mode map for userID=%d: %v", userID, err))
+			return ctx.Respond(&telebot.CallbackResponse{Text: bot.Replies.Error, ShowAlert: true})
+		}
+		_ = ctx.Respond()
+		return ctx.Edit(autoModeText(bot, autoMap), autoModeMarkup(bot, autoMap))
+	}
+}
+
 func createCloseModeHandler(bot *b.Bot) telebot.HandlerFunc {
 	return func(ctx telebot.Context) error {
 		if ctx.Message() != nil {
@@ -90,31 +125,23 @@ func createCloseModeHandler(bot *b.Bot) telebot.HandlerFunc {
 	}
 }
 
+// changeModeMarkup lists the registry and mix/auto modes except the current one; auto stays so
+// that its time-of-day settings can be reopened.
 func changeModeMarkup(current models.UserMode) *telebot.ReplyMarkup {
-	type option struct {
-		mode   models.UserMode
-		text   string
-		unique string
-	}
-
-	options := []option{
-		{mode: constants.RoflMode, text: btnModeRofl.Text, unique: btnModeRofl.Unique},
-		{mode: constants.CozyMode, text: btnModeCozy.Text, unique: btnModeCozy.Unique},
-		{mode: constants.CareMode, text: btnModeCare.Text, unique: btnModeCare.Unique},
-	}
+	options := modes.Choices()
 
 	markup := &telebot.ReplyMarkup{}
-	rows := make([]telebot.Row, 0, len(options))
+	rows := make([]telebot.Row, 0, len(options)+1)
 	for _, opt := range options {
-		if current != "" && opt.mode == current {
+		if current != "" && opt.Key == current && current != constants.AutoMode {
 			continue
 		}
-		rows = append(rows, markup.Row(markup.Data(opt.text, opt.unique, string(opt.mode))))
+		rows = append(rows, markup.Row(markup.Data(opt.Button, btnModeSelect.Unique, string(opt.Key))))
 	}
 
 	if len(rows) == 0 {
 		for _, opt := range options {
-			rows = append(rows, markup.Row(markup.Data(opt.text, opt.unique, string(opt.mode))))
+			rows = append(rows, markup.Row(markup.Data(opt.Button, btnModeSelect.Unique, string(opt.Key))))
 		}
 	}
 
@@ -123,3 +150,38 @@ func changeModeMarkup(current models.UserMode) *telebot.ReplyMarkup {
 	markup.Inline(rows...)
 	return markup
 }
+
+func autoModeText(bot *b.Bot, autoMap map[string]models.UserMode) string {
+	var builder strings.Builder
+	builder.WriteString(bot.Replies.AutoModeSettings)
+	for _, bucket := range modes.AutoBuckets {
+		builder.WriteString(fmt.Sprintf(bot.Replies.AutoModeRow, autoBucketName(bot, bucket), helpers.HumanModeName(autoMap[bucket])))
+	}
+	return builder.String()
+}
+
+// autoModeMarkup has one button per time-of-day bucket; pressing it cycles the bucket's style.
+func autoModeMarkup(bot *b.Bot, autoMap map[string]models.UserMode) *telebot.ReplyMarkup {
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, len(modes.AutoBuckets)+1)
+	for _, bucket := range modes.AutoBuckets {
+		label := fmt.Sprintf("%s: %s", autoBucketName(bot, bucket), modes.Resolve(autoMap[bucket]).Button)
+		rows = append(rows, markup.Row(markup.Data(label, btnAutoModeCycle.Unique, bucket)))
+	}
+	rows = append(rows, markup.Row(btnModeClose))
+	markup.Inline(rows...)
+	return markup
+}
+
+func autoBucketName(bot *b.Bot, bucket string) string {
+	switch bucket {
+	case "morning":
+		return bot.Replies.AutoModeMorning
+	case "day":
+		return bot.Replies.AutoModeDay
+	case "evening":
+		return bot.Replies.AutoModeEvening
+	default:
+		return bot.Replies.AutoModeNight
+	}
+}
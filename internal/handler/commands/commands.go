@@ -15,6 +15,8 @@ var commands = []telebot.Command{
 	{Text: "change_interval", Description: "Сменить частоту напоминаний"},
 	{Text: "change_daytime", Description: "Настроить время для уведомлений"},
 	{Text: "toggle_notifications", Description: "Включить/выключить уведомления"},
+	{Text: "export_reminders", Description: "Выгрузить напоминания в .ics"},
+	{Text: "export_items", Description: "Выгрузить вещи в файл (JSON/CSV)"},
 }
 
 func MustInitCommandsHandler(bot *bot.Bot) {
@@ -29,4 +31,6 @@ func MustInitCommandsHandler(bot *bot.Bot) {
 	initChangeIntervalHandler(bot)
 	initToggleNotificationsHandler(bot)
 	initChangeDaytimeHandler(bot)
+	initExportRemindersHandler(bot)
+	initExportItemsHandler(bot)
 }
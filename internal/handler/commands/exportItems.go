@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/feat/items"
+	"safeboxtgbot/internal/middleware/auth"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+func initExportItemsHandler(bot *b.Bot) {
+	bot.Handle("/export_items", createExportItemsHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+// createExportItemsHandler sends the item box as JSON; "/export_items csv" sends CSV instead.
+func createExportItemsHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		list, err := bot.ItemsService.GetItemList(userID)
+		if err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error loading items for export userID=%d: %v", userID, err))
+			return ctx.Send(bot.Replies.Error)
+		}
+		if len(list) == 0 {
+			return ctx.Send(bot.Replies.ItemsExportEmpty)
+		}
+
+		format := items.ExportFormatJSON
+		if strings.EqualFold(strings.TrimSpace(ctx.Message().Payload), string(items.ExportFormatCSV)) {
+			format = items.ExportFormatCSV
+		}
+		data, err := items.Export(list, format, time.Now())
+		if err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error exporting items for userID=%d: %v", userID, err))
+			return ctx.Send(bot.Replies.Error)
+		}
+
+		doc := &telebot.Document{
+			File:     telebot.FromReader(bytes.NewReader(data)),
+			FileName: "items." + string(format),
+			Caption:  bot.Replies.ItemsExportCaption,
+		}
+		if msg := bot.MustSend(userID, doc); msg == nil {
+			return ctx.Send(bot.Replies.Error)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/feat/reminder"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/internal/middleware/auth"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+const remindersICSFileName = "reminders.ics"
+
+func initExportRemindersHandler(bot *b.Bot) {
+	bot.Handle("/export_reminders", createExportRemindersHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+func createExportRemindersHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		user := bot.UserService.GetUser(userID)
+		if user == nil || user.TelegramID == 0 {
+			return nil
+		}
+
+		list, err := bot.ReminderService.GetList(userID)
+		if err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error loading reminders for export userID=%d: %v", userID, err))
+			return ctx.Send(bot.Replies.Error)
+		}
+		hasEnabled := false
+		for _, r := range list {
+			hasEnabled = hasEnabled || r.Enabled
+		}
+		if !hasEnabled {
+			return ctx.Send(bot.Replies.ICSExportEmpty)
+		}
+
+		loc, err := helpers.UserLocation(*user)
+		if err != nil {
+			loc = time.UTC
+		}
+		data := reminder.ExportICS(list, time.Now(), loc)
+		doc := &telebot.Document{
+			File:     telebot.FromReader(bytes.NewReader(data)),
+			FileName: remindersICSFileName,
+			MIME:     "text/calendar",
+			Caption:  bot.Replies.ICSExportCaption,
+		}
+		if msg := bot.MustSend(userID, doc); msg == nil {
+			return ctx.Send(bot.Replies.Error)
+		}
+		return nil
+	}
+}
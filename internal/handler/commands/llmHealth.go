@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"html"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/feat/prompt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+func createLLMHealthHandler(bot *b.Bot, health prompt.HealthReporter) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		providers := health.Health()
+		if len(providers) == 0 {
+			return ctx.Send("No LLM providers configured")
+		}
+
+		var builder strings.Builder
+		builder.WriteString("LLM providers (in chain order):\n")
+		for i, p := range providers {
+			builder.WriteString(fmt.Sprintf("\n%d. %s — %s, failures in a row: %d", i+1, html.EscapeString(p.Name), p.State, p.ConsecutiveFailures))
+			if p.State == prompt.CircuitOpen {
+				builder.WriteString(fmt.Sprintf("\n   next probe: %s", formatHealthTime(p.RetryAt)))
+			}
+			builder.WriteString(fmt.Sprintf("\n   last success: %s", formatHealthTime(p.LastSuccessAt)))
+			if !p.LastFailureAt.IsZero() {
+				builder.WriteString(fmt.Sprintf("\n   last failure: %s — %s", formatHealthTime(p.LastFailureAt), html.EscapeString(p.LastError)))
+			}
+			if len(p.RejectedOutputs) > 0 {
+				builder.WriteString(fmt.Sprintf("\n   rejected outputs: %s", formatRejections(p.RejectedOutputs)))
+			}
+			if len(p.FlaggedOutputs) > 0 {
+				builder.WriteString(fmt.Sprintf("\n   flagged outputs (kept): %s", formatRejections(p.FlaggedOutputs)))
+			}
+		}
+		return ctx.Send(builder.String())
+	}
+}
+
+func formatHealthTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+func formatRejections(byRule map[string]int) string {
+	rules := make([]string, 0, len(byRule))
+	for rule := range byRule {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	total := 0
+	parts := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		total += byRule[rule]
+		parts = append(parts, fmt.Sprintf("%s: %d", rule, byRule[rule]))
+	}
+	return fmt.Sprintf("%d (%s)", total, strings.Join(parts, ", "))
+}
@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"fmt"
+	"html"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/config"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/repo"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+const (
+	llmUsageDefaultDays = 7
+	llmUsageMaxDays     = 90
+	llmUsageTopUsers    = 10
+)
+
+type usageTotals struct {
+	Calls            int64
+	Failed           int64
+	PromptTokens     int64
+	CompletionTokens int64
+	LatencyMs        int64
+	Cost             float64
+}
+
+func (t *usageTotals) add(row repo.LLMUsageRow, cost float64) {
+	t.Calls += row.Calls
+	t.Failed += row.Failed
+	t.PromptTokens += row.PromptTokens
+	t.CompletionTokens += row.CompletionTokens
+	t.LatencyMs += row.LatencyMs
+	t.Cost += cost
+}
+
+type usageLine struct {
+	Label string
+	usageTotals
+}
+
+type usageSummary struct {
+	Days     []usageLine
+	Models   []usageLine
+	Users    []usageLine
+	Total    usageTotals
+	Unpriced []string
+}
+
+// createLLMUsageHandler reports LLM calls of the last N days (/llm_usage [days]).
+func createLLMUsageHandler(bot *b.Bot, usageRepo *repo.LLMCallRepo, prices config.LLMPrices) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		days := llmUsageDefaultDays
+		if args := ctx.Args(); len(args) > 0 {
+			parsed, err := strconv.Atoi(args[0])
+			if err != nil || parsed < 1 || parsed > llmUsageMaxDays {
+				return ctx.Send(fmt.Sprintf("Usage: /llm_usage [days], 1-%d", llmUsageMaxDays))
+			}
+			days = parsed
+		}
+
+		now := time.Now().UTC()
+		since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1-days)
+		rows, err := usageRepo.Usage(since)
+		if err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error loading LLM usage: %v", err))
+			return ctx.Send(bot.Replies.Error)
+		}
+		if len(rows) == 0 {
+			return ctx.Send(fmt.Sprintf("No LLM calls in the last %d days", days))
+		}
+		for _, chunk := range splitMessage(formatUsageSummary(days, summarizeUsage(rows, prices, llmUsageTopUsers)), constants.MaxMessageLen) {
+			if err := ctx.Send(chunk); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// splitMessage cuts text into messages of at most limit runes, breaking between lines.
+// A single line longer than limit is cut at the limit.
+func splitMessage(text string, limit int) []string {
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+	flush := func() {
+		if chunk := strings.TrimSpace(current.String()); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+		currentLen = 0
+	}
+	for _, line := range strings.SplitAfter(text, "\n") {
+		runes := []rune(line)
+		for len(runes) > limit {
+			flush()
+			chunks = append(chunks, string(runes[:limit]))
+			runes = runes[limit:]
+		}
+		if currentLen+len(runes) > limit {
+			flush()
+		}
+		current.WriteString(string(runes))
+		currentLen += len(runes)
+	}
+	flush()
+	return chunks
+}
+
+// summarizeUsage folds the grouped rows into per-day, per-model and top-user totals with
+// estimated costs; models missing from prices count as free and are listed in Unpriced.
+func summarizeUsage(rows []repo.LLMUsageRow, prices config.LLMPrices, topUsers int) usageSummary {
+	days := make(map[string]*usageLine)
+	modelLines := make(map[string]*usageLine)
+	users := make(map[string]*usageLine)
+	unpriced := make(map[string]struct{})
+	summary := usageSummary{}
+
+	line := func(lines map[string]*usageLine, label string) *usageLine {
+		if existing, ok := lines[label]; ok {
+			return existing
+		}
+		created := &usageLine{Label: label}
+		lines[label] = created
+		return created
+	}
+
+	for _, row := range rows {
+		cost, ok := prices.Cost(row.Model, row.PromptTokens, row.CompletionTokens)
+		if !ok && row.PromptTokens+row.CompletionTokens > 0 {
+			unpriced[modelLabel(row.Model)] = struct{}{}
+		}
+		user := "no user"
+		if row.UserID != 0 {
+			user = strconv.FormatInt(row.UserID, 10)
+		}
+		line(days, row.Day).add(row, cost)
+		line(modelLines, row.Provider+" / "+modelLabel(row.Model)).add(row, cost)
+		line(users, user).add(row, cost)
+		summary.Total.add(row, cost)
+	}
+
+	summary.Days = sortedLines(days, func(a, b usageLine) bool { return a.Label > b.Label })
+	summary.Models = sortedLines(modelLines, byCostThenTokens)
+	summary.Users = sortedLines(users, byCostThenTokens)
+	if len(summary.Users) > topUsers {
+		summary.Users = summary.Users[:topUsers]
+	}
+	for model := range unpriced {
+		summary.Unpriced = append(summary.Unpriced, model)
+	}
+	sort.Strings(summary.Unpriced)
+	return summary
+}
+
+func byCostThenTokens(a, b usageLine) bool {
+	if a.Cost != b.Cost {
+		return a.Cost > b.Cost
+	}
+	if tokensA, tokensB := a.PromptTokens+a.CompletionTokens, b.PromptTokens+b.CompletionTokens; tokensA != tokensB {
+		return tokensA > tokensB
+	}
+	return a.Label < b.Label
+}
+
+func sortedLines(lines map[string]*usageLine, less func(a, b usageLine) bool) []usageLine {
+	out := make([]usageLine, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, *line)
+	}
+	sort.Slice(out, func(i, j int) bool { return less(out[i], out[j]) })
+	return out
+}
+
+func modelLabel(model string) string {
+	if model == "" {
+		return "unknown model"
+	}
+	return model
+}
+
+func formatUsageSummary(days int, summary usageSummary) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("LLM usage for the last %d days (UTC)\n", days))
+	builder.WriteString(fmt.Sprintf("\nTotal: %s\n", formatUsageTotals(summary.Total, true)))
+
+	sections := []struct {
+		title string
+		lines []usageLine
+	}{
+		{title: "By day", lines: summary.Days},
+		{title: "By provider / model", lines: summary.Models},
+		{title: fmt.Sprintf("Top %d users", llmUsageTopUsers), lines: summary.Users},
+	}
+	for _, section := range sections {
+		builder.WriteString(fmt.Sprintf("\n%s:\n", section.title))
+		for _, line := range section.lines {
+			builder.WriteString(fmt.Sprintf("%s: %s\n", html.EscapeString(line.Label), formatUsageTotals(line.usageTotals, section.title == "By provider / model")))
+		}
+	}
+	if len(summary.Unpriced) > 0 {
+		builder.WriteString(fmt.Sprintf("\nNo price in LLM_PRICES (counted as $0): %s\n", html.EscapeString(strings.Join(summary.Unpriced, ", "))))
+	}
+	return builder.String()
+}
+
+func formatUsageTotals(t usageTotals, withLatency bool) string {
+	text := fmt.Sprintf("%d calls, %d failed, %d tokens (in %d / out %d), ~$%.4f",
+		t.Calls, t.Failed, t.PromptTokens+t.CompletionTokens, t.PromptTokens, t.CompletionTokens, t.Cost)
+	if withLatency && t.Calls > 0 {
+		text += fmt.Sprintf(", avg %.1fs", float64(t.LatencyMs)/float64(t.Calls)/1000)
+	}
+	return text
+}
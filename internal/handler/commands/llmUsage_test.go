@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"safeboxtgbot/internal/core/config"
+	"safeboxtgbot/internal/repo"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSummarizeUsage(t *testing.T) {
+	prices := config.LLMPrices{"paid": {Prompt: 1, Completion: 2}}
+	rows := []repo.LLMUsageRow{
+		{Day: "2025-05-01", UserID: 1, Provider: "p", Model: "paid", Calls: 2, Failed: 1, PromptTokens: 1_000_000, CompletionTokens: 500_000, LatencyMs: 3000},
+		{Day: "2025-05-02", UserID: 1, Provider: "p", Model: "paid", Calls: 1, PromptTokens: 1_000_000, LatencyMs: 1000},
+		{Day: "2025-05-02", UserID: 2, Provider: "p", Model: "free", Calls: 4, PromptTokens: 10, CompletionTokens: 5},
+		{Day: "2025-05-02", UserID: 3, Provider: "p", Model: "", Calls: 1, Failed: 1},
+	}
+
+	summary := summarizeUsage(rows, prices, 2)
+	if summary.Total.Calls != 8 || summary.Total.Failed != 2 || summary.Total.Cost != 3 {
+		t.Fatalf("unexpected total: %+v", summary.Total)
+	}
+	if len(summary.Days) != 2 || summary.Days[0].Label != "2025-05-02" || summary.Days[1].Cost != 2 {
+		t.Fatalf("days must be newest first with their cost: %+v", summary.Days)
+	}
+	if len(summary.Models) != 3 || summary.Models[0].Label != "p / paid" || summary.Models[0].Calls != 3 {
+		t.Fatalf("models must be sorted by cost: %+v", summary.Models)
+	}
+	if len(summary.Users) != 2 || summary.Users[0].Label != "1" || summary.Users[1].Label != "2" {
+		t.Fatalf("users must be cut to the top by cost, then tokens: %+v", summary.Users)
+	}
+	if len(summary.Unpriced) != 1 || summary.Unpriced[0] != "free" {
+		t.Fatalf("only models with tokens and no price are unpriced: %v", summary.Unpriced)
+	}
+}
+
+func TestSplitMessage(t *testing.T) {
+	lines := make([]string, 0, 300)
+	for i := 0; i < 300; i++ {
+		lines = append(lines, "2025-05-01: 12 calls, 0 failed, 3456 tokens")
+	}
+	text := strings.Join(lines, "\n") + "\n" + strings.Repeat("я", 250)
+
+	chunks := splitMessage(text, 200)
+	var joined []string
+	for _, chunk := range chunks {
+		if n := utf8.RuneCountInString(chunk); n == 0 || n > 200 {
+			t.Fatalf("chunk of %d runes", n)
+		}
+		joined = append(joined, chunk)
+	}
+	if got := strings.ReplaceAll(strings.Join(joined, "\n"), "\n", ""); got != strings.ReplaceAll(text, "\n", "") {
+		t.Fatal("chunks must keep the whole text")
+	}
+	if len(splitMessage("short", 200)) != 1 {
+		t.Fatal("short text must stay one message")
+	}
+}
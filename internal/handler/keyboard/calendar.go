@@ -0,0 +1,113 @@
+package keyboard
+
+import (
+	"fmt"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/helpers"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+const (
+	calendarDayLayout   = "2006-01-02"
+	calendarMonthLayout = "2006-01"
+	calendarMaxMonths   = 12
+)
+
+// btnPickerNoop backs inert cells (headers, blanks, past days) of the pickers.
+var btnPickerNoop = telebot.Btn{Unique: "btn_picker_noop"}
+
+func MustInitPickerButtons(bot *b.Bot) {
+	bot.Handle(&btnPickerNoop, func(ctx telebot.Context) error {
+		bot.RespondSilently(ctx)
+		return nil
+	})
+}
+
+// calendarMarkup renders a Monday-first month grid. Days before minDate are inert,
+// paging is limited to [minDate month, minDate month + calendarMaxMonths].
+func calendarMarkup(month, minDate time.Time, dayBtn, navBtn telebot.Btn, footer ...telebot.Row) *telebot.ReplyMarkup {
+	loc := minDate.Location()
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, loc)
+	minDay := time.Date(minDate.Year(), minDate.Month(), minDate.Day(), 0, 0, 0, 0, loc)
+	minMonth := time.Date(minDate.Year(), minDate.Month(), 1, 0, 0, 0, 0, loc)
+	maxMonth := minMonth.AddDate(0, calendarMaxMonths, 0)
+	if first.Before(minMonth) {
+		first = minMonth
+	}
+	if first.After(maxMonth) {
+		first = maxMonth
+	}
+
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, 10+len(footer))
+
+	title := fmt.Sprintf("%s %d", constants.MonthNamesRu[int(first.Month())-1], first.Year())
+	rows = append(rows, markup.Row(noopBtn(markup, title)))
+
+	header := make([]telebot.Btn, 0, constants.DaysInWeek)
+	for _, name := range constants.WeekdayShortRu {
+		header = append(header, noopBtn(markup, name))
+	}
+	rows = append(rows, markup.Row(header...))
+
+	offset := (int(first.Weekday()) + 6) % constants.DaysInWeek
+	days := helpers.DaysInMonth(first.Year(), first.Month())
+	week := make([]telebot.Btn, 0, constants.DaysInWeek)
+	for i := 0; i < offset; i++ {
+		week = append(week, noopBtn(markup, " "))
+	}
+	for day := 1; day <= days; day++ {
+		date := time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, loc)
+		if date.Before(minDay) {
+			week = append(week, noopBtn(markup, "·"))
+		} else {
+			week = append(week, markup.Data(fmt.Sprintf("%d", day), dayBtn.Unique, date.Format(calendarDayLayout)))
+		}
+		if len(week) == constants.DaysInWeek {
+			rows = append(rows, markup.Row(week...))
+			week = make([]telebot.Btn, 0, constants.DaysInWeek)
+		}
+	}
+	if len(week) > 0 {
+		for len(week) < constants.DaysInWeek {
+			week = append(week, noopBtn(markup, " "))
+		}
+		rows = append(rows, markup.Row(week...))
+	}
+
+	prev := noopBtn(markup, " ")
+	if first.After(minMonth) {
+		prev = markup.Data("◀️", navBtn.Unique, first.AddDate(0, -1, 0).Format(calendarMonthLayout))
+	}
+	next := noopBtn(markup, " ")
+	if first.Before(maxMonth) {
+		next = markup.Data("▶️", navBtn.Unique, first.AddDate(0, 1, 0).Format(calendarMonthLayout))
+	}
+	rows = append(rows, markup.Row(prev, next))
+	rows = append(rows, footer...)
+
+	markup.Inline(rows...)
+	return markup
+}
+
+func parseCalendarDay(raw string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.ParseInLocation(calendarDayLayout, strings.TrimSpace(raw), loc)
+}
+
+func parseCalendarMonth(raw string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.ParseInLocation(calendarMonthLayout, strings.TrimSpace(raw), loc)
+}
+
+func noopBtn(markup *telebot.ReplyMarkup, text string) telebot.Btn {
+	return markup.Data(text, btnPickerNoop.Unique, "-")
+}
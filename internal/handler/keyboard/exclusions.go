@@ -0,0 +1,303 @@
+package keyboard
+
+import (
+	"errors"
+	"fmt"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/feat/holiday"
+	"safeboxtgbot/internal/feat/reminder"
+	"safeboxtgbot/internal/middleware/auth"
+	"safeboxtgbot/models"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	btnReminderExclusions       = telebot.Btn{Unique: "btn_reminder_exclusions", Text: "🚫 Исключения"}
+	btnReminderAlwaysFire       = telebot.Btn{Unique: "btn_reminder_always_fire", Text: "🔁 Без исключений"}
+	btnBackToExclusions         = telebot.Btn{Unique: "btn_back_to_exclusions", Text: "⬅️ Назад"}
+	btnExclusionAdd             = telebot.Btn{Unique: "btn_exclusion_add", Text: "➕ Добавить дату"}
+	btnExclusionRemove          = telebot.Btn{Unique: "btn_exclusion_remove", Text: "🗑 Убрать дату"}
+	btnExclusionHolidays        = telebot.Btn{Unique: "btn_exclusion_holidays"}
+	btnExclusionRemoveSelect    = telebot.Btn{Unique: "btn_exclusion_remove_select"}
+	btnExclusionCalendarDay     = telebot.Btn{Unique: "btn_exclusion_calendar_day"}
+	btnExclusionCalendarNav     = telebot.Btn{Unique: "btn_exclusion_calendar_nav"}
+	btnSelectReminderAlwaysFire = telebot.Btn{Unique: "btn_select_reminder_always_fire"}
+)
+
+func MustInitExclusionButtons(bot *b.Bot) {
+	bot.Handle(&btnReminderExclusions, createOpenExclusionsHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnBackToExclusions, createOpenExclusionsHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnExclusionAdd, createExclusionAddHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnExclusionRemove, createExclusionRemoveHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnExclusionRemoveSelect, createExclusionRemoveSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnExclusionHolidays, createExclusionHolidaysHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnExclusionCalendarDay, createExclusionCalendarDayHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnExclusionCalendarNav, createExclusionCalendarNavHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnReminderAlwaysFire, createAlwaysFireListHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSelectReminderAlwaysFire, createAlwaysFireToggleHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+func createOpenExclusionsHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ReminderService.ClearPending(userID)
+		return renderExclusions(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createExclusionAddHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		return renderExclusionCalendar(bot, userID, ctx.Message(), time.Now().In(safeUserLoc(bot, userID)))
+	}
+}
+
+func createExclusionCalendarNavHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		month, err := parseCalendarMonth(ctx.Data(), safeUserLoc(bot, userID))
+		if err != nil {
+			return renderExclusions(bot, userID, ctx.Message(), "")
+		}
+		return renderExclusionCalendar(bot, userID, ctx.Message(), month)
+	}
+}
+
+func createExclusionCalendarDayHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		loc := safeUserLoc(bot, userID)
+		day, err := parseCalendarDay(ctx.Data(), loc)
+		if err != nil {
+			return renderExclusions(bot, userID, ctx.Message(), bot.Replies.ExclusionDateInvalid)
+		}
+		if err := bot.ExclusionService.Add(userID, day); err != nil {
+			return handleExclusionError(bot, userID, ctx.Message(), err)
+		}
+		refreshReminderSchedules(bot, userID, loc)
+		return renderExclusions(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createExclusionRemoveHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		dates, err := upcomingExcludedDates(bot, userID)
+		if err != nil {
+			return upsertReminderLastMessage(bot, userID, ctx.Message(), bot.Replies.Error, exclusionsMarkup(bot, userID))
+		}
+		text := bot.Replies.WhatDoWeDelete
+		if len(dates) == 0 {
+			text = bot.Replies.ListIsEmpty
+		}
+		return upsertReminderLastMessage(bot, userID, ctx.Message(), text, selectExcludedDateMarkup(dates))
+	}
+}
+
+func createExclusionRemoveSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		if err := bot.ExclusionService.Remove(userID, strings.TrimSpace(ctx.Data())); err != nil {
+			return handleExclusionError(bot, userID, ctx.Message(), err)
+		}
+		refreshReminderSchedules(bot, userID, safeUserLoc(bot, userID))
+		return renderExclusions(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createExclusionHolidaysHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		if err := bot.ExclusionService.SetHolidayCalendar(userID, strings.TrimSpace(ctx.Data())); err != nil {
+			return handleExclusionError(bot, userID, ctx.Message(), err)
+		}
+		refreshReminderSchedules(bot, userID, safeUserLoc(bot, userID))
+		return renderExclusions(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createAlwaysFireListHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ReminderService.ClearPending(userID)
+		return renderAlwaysFireSelect(bot, userID, ctx.Message())
+	}
+}
+
+func createAlwaysFireToggleHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		reminderID, err := parseUintData(ctx)
+		if err != nil {
+			return renderAlwaysFireSelect(bot, userID, ctx.Message())
+		}
+		list, err := bot.ReminderService.GetList(userID)
+		if err != nil {
+			return upsertReminderLastMessage(bot, userID, ctx.Message(), bot.Replies.Error, reminderBoxMarkup())
+		}
+		for _, r := range list {
+			if r.ID != reminderID {
+				continue
+			}
+			if err := bot.ReminderService.SetAlwaysFire(r.ID, userID, !r.AlwaysFire, time.Now().UTC(), safeUserLoc(bot, userID)); err != nil && !errors.Is(err, reminder.ErrReminderNotFound) {
+				bot.Logger.Error(fmt.Sprintf("Error toggling always-fire for reminder %d userID=%d: %v", r.ID, userID, err))
+				return upsertReminderLastMessage(bot, userID, ctx.Message(), bot.Replies.Error, reminderBoxMarkup())
+			}
+			break
+		}
+		return renderAlwaysFireSelect(bot, userID, ctx.Message())
+	}
+}
+
+func renderExclusions(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	dates, err := upcomingExcludedDates(bot, userID)
+	if err != nil {
+		return upsertReminderLastMessage(bot, userID, sourceMsg, bot.Replies.Error, reminderBoxMarkup())
+	}
+
+	var builder strings.Builder
+	if note != "" {
+		builder.WriteString(note + "\n\n")
+	}
+	builder.WriteString(fmt.Sprintf(bot.Replies.ExclusionsHeader, holidayCalendarName(bot, userID)))
+	if len(dates) == 0 {
+		builder.WriteString(bot.Replies.ExclusionsEmpty)
+	}
+	for _, d := range dates {
+		builder.WriteString(fmt.Sprintf(bot.Replies.ExclusionsItemRow, formatExcludedDate(d.Date)))
+	}
+	return upsertReminderLastMessage(bot, userID, sourceMsg, builder.String(), exclusionsMarkup(bot, userID))
+}
+
+func renderExclusionCalendar(bot *b.Bot, userID int64, sourceMsg *telebot.Message, month time.Time) error {
+	today := time.Now().In(safeUserLoc(bot, userID))
+	markup := calendarMarkup(month, today, btnExclusionCalendarDay, btnExclusionCalendarNav, telebot.Row{btnBackToExclusions})
+	return upsertReminderLastMessage(bot, userID, sourceMsg, bot.Replies.ExclusionDatePrompt, markup)
+}
+
+func renderAlwaysFireSelect(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
+	list, err := bot.ReminderService.GetList(userID)
+	if err != nil {
+		return upsertReminderLastMessage(bot, userID, sourceMsg, bot.Replies.Error, reminderBoxMarkup())
+	}
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, len(list)+1)
+	for _, r := range list {
+		if r.Schedule == models.ReminderScheduleOnce {
+			continue
+		}
+		mark := "🚫"
+		if r.AlwaysFire {
+			mark = "🔁"
+		}
+		rows = append(rows, markup.Row(markup.Data(mark+" "+r.Name, btnSelectReminderAlwaysFire.Unique, fmt.Sprintf("%d", r.ID))))
+	}
+	text := bot.Replies.ReminderAlwaysFirePrompt
+	if len(rows) == 0 {
+		text = bot.Replies.ListIsEmpty
+	}
+	rows = append(rows, markup.Row(btnBackToReminderBox))
+	markup.Inline(rows...)
+	return upsertReminderLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func exclusionsMarkup(bot *b.Bot, userID int64) *telebot.ReplyMarkup {
+	markup := &telebot.ReplyMarkup{}
+	next := nextHolidayCalendarCode(bot.UserService.GetUser(userID).HolidayCalendar)
+	holidaysText := fmt.Sprintf(bot.Replies.ExclusionsHolidaysButton, holidayCalendarName(bot, userID))
+	markup.Inline(
+		markup.Row(btnExclusionAdd, btnExclusionRemove),
+		markup.Row(markup.Data(holidaysText, btnExclusionHolidays.Unique, next)),
+		markup.Row(btnBackToReminderBox),
+	)
+	return markup
+}
+
+func selectExcludedDateMarkup(dates []models.ExcludedDate) *telebot.ReplyMarkup {
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, len(dates)+1)
+	for _, d := range dates {
+		rows = append(rows, markup.Row(markup.Data(formatExcludedDate(d.Date), btnExclusionRemoveSelect.Unique, d.Date)))
+	}
+	rows = append(rows, markup.Row(btnBackToExclusions))
+	markup.Inline(rows...)
+	return markup
+}
+
+// upcomingExcludedDates hides past days: they no longer affect scheduling.
+func upcomingExcludedDates(bot *b.Bot, userID int64) ([]models.ExcludedDate, error) {
+	dates, err := bot.ExclusionService.GetList(userID)
+	if err != nil {
+		return nil, err
+	}
+	today := time.Now().In(safeUserLoc(bot, userID)).Format(time.DateOnly)
+	upcoming := make([]models.ExcludedDate, 0, len(dates))
+	for _, d := range dates {
+		if d.Date >= today {
+			upcoming = append(upcoming, d)
+		}
+	}
+	return upcoming, nil
+}
+
+func holidayCalendarName(bot *b.Bot, userID int64) string {
+	if cal, ok := holiday.Lookup(bot.UserService.GetUser(userID).HolidayCalendar); ok {
+		return cal.Name
+	}
+	return bot.Replies.ExclusionsHolidaysOff
+}
+
+// nextHolidayCalendarCode cycles off -> each bundled calendar -> off.
+func nextHolidayCalendarCode(current string) string {
+	codes := holiday.Codes()
+	if current == "" {
+		if len(codes) == 0 {
+			return ""
+		}
+		return codes[0]
+	}
+	for i, code := range codes {
+		if code == current && i+1 < len(codes) {
+			return codes[i+1]
+		}
+	}
+	return ""
+}
+
+func formatExcludedDate(date string) string {
+	parsed, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return date
+	}
+	return parsed.Format("02.01.2006")
+}
+
+func refreshReminderSchedules(bot *b.Bot, userID int64, loc *time.Location) {
+	if err := bot.ReminderService.RefreshSchedules(userID, time.Now().UTC(), loc); err != nil {
+		bot.Logger.Error(fmt.Sprintf("Error refreshing reminder schedules for userID=%d: %v", userID, err))
+	}
+}
+
+func handleExclusionError(bot *b.Bot, userID int64, sourceMsg *telebot.Message, err error) error {
+	switch {
+	case errors.Is(err, reminder.ErrExcludedDateLimit):
+		return renderExclusions(bot, userID, sourceMsg, bot.Replies.ExclusionsLimitReached)
+	case errors.Is(err, reminder.ErrExcludedDateInvalid), errors.Is(err, reminder.ErrUnknownHolidayCalendar):
+		return renderExclusions(bot, userID, sourceMsg, bot.Replies.ExclusionDateInvalid)
+	default:
+		bot.Logger.Error(fmt.Sprintf("Exclusions error for userID=%d: %v", userID, err))
+		return upsertReminderLastMessage(bot, userID, sourceMsg, bot.Replies.Error, reminderBoxMarkup())
+	}
+}
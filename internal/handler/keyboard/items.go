@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
 	b "safeboxtgbot/internal"
 	"safeboxtgbot/internal/feat/items"
 	fsmManager "safeboxtgbot/internal/fsm"
@@ -11,6 +12,7 @@ import (
 	"safeboxtgbot/internal/middleware/auth"
 	"safeboxtgbot/models"
 	"strings"
+	"time"
 
 	"gopkg.in/telebot.v4"
 )
@@ -36,10 +38,16 @@ func MustInitItemBoxButtons(bot *b.Bot) {
 }
 
 func OpenItemBox(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
+	return OpenItemBoxWithNote(bot, userID, sourceMsg, "")
+}
+
+func OpenItemBoxWithNote(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
 	clearClosedItemBoxMessage(bot, userID)
 	bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemsMenuOpenedEvent)
 	bot.ItemsService.ClearEditingItemID(userID)
-	return renderItemBox(bot, userID, sourceMsg)
+	bot.ItemsService.ResetPaging(userID)
+	bot.TagsService.SetFilter(userID, 0)
+	return renderItemBoxWithNote(bot, userID, sourceMsg, note)
 }
 
 func createAddItemHandler(bot *b.Bot) telebot.HandlerFunc {
@@ -55,6 +63,10 @@ func createAddItemHandler(bot *b.Bot) telebot.HandlerFunc {
 func CreateValidateAddItemHandler(bot *b.Bot) telebot.HandlerFunc {
 	return func(ctx telebot.Context) error {
 		userID := ctx.Chat().ID
+		if len(items.SplitItemNames(ctx.Message().Text)) > 1 {
+			return addItemsBulk(bot, userID, ctx.Message())
+		}
+
 		err := bot.ItemsService.CreateItem(userID, ctx.Message().Text)
 		bot.MustDelete(ctx.Message())
 		if err != nil {
@@ -67,12 +79,55 @@ func CreateValidateAddItemHandler(bot *b.Bot) telebot.HandlerFunc {
 	}
 }
 
+func addItemsBulk(bot *b.Bot, userID int64, msg *telebot.Message) error {
+	results, err := bot.ItemsService.CreateItems(userID, msg.Text)
+	bot.MustDelete(msg)
+	if err != nil {
+		bot.Logger.Error(fmt.Sprintf("Error bulk adding items for userID=%d: %v", userID, err))
+		return handleItemInputError(bot, userID, err, true)
+	}
+
+	bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemsMenuOpenedEvent)
+	bot.ItemsService.ClearEditingItemID(userID)
+	return renderItemBoxWithNote(bot, userID, nil, bulkAddReport(bot, results))
+}
+
+func bulkAddReport(bot *b.Bot, results []items.ItemAddResult) string {
+	var builder strings.Builder
+	added := 0
+	for _, res := range results {
+		if res.Status == items.ItemAdded {
+			added++
+		}
+	}
+	builder.WriteString(fmt.Sprintf(bot.Replies.ItemsBulkHeader, added, len(results)))
+	for _, res := range results {
+		name := res.Name
+		if name == "" {
+			name = res.Input
+		}
+		name = html.EscapeString(name)
+		switch res.Status {
+		case items.ItemAdded:
+			builder.WriteString(fmt.Sprintf(bot.Replies.ItemsBulkAdded, name))
+		case items.ItemAddDuplicate:
+			builder.WriteString(fmt.Sprintf(bot.Replies.ItemsBulkDuplicate, name))
+		case items.ItemAddTooLong:
+			builder.WriteString(fmt.Sprintf(bot.Replies.ItemsBulkTooLong, name))
+		case items.ItemAddLimitReached:
+			builder.WriteString(fmt.Sprintf(bot.Replies.ItemsBulkLimitReached, name))
+		}
+	}
+	return strings.TrimRight(builder.String(), "\n")
+}
+
 func createEditItemHandler(bot *b.Bot) telebot.HandlerFunc {
 	return func(ctx telebot.Context) error {
 		userID := ctx.Chat().ID
 		bot.RespondSilently(ctx)
 		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemEditSelectOpenedEvent)
 		bot.ItemsService.ClearEditingItemID(userID)
+		bot.ItemsService.ResetPaging(userID)
 		return renderEditItemSelectPrompt(bot, userID, ctx.Message())
 	}
 }
@@ -104,6 +159,7 @@ func createDeleteItemHandler(bot *b.Bot) telebot.HandlerFunc {
 		bot.RespondSilently(ctx)
 		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemDeleteSelectOpenedEvent)
 		bot.ItemsService.ClearEditingItemID(userID)
+		bot.ItemsService.ResetPaging(userID)
 		return renderDeleteSelect(bot, userID, ctx.Message())
 	}
 }
@@ -129,6 +185,7 @@ func createBackToItemBoxHandler(bot *b.Bot) telebot.HandlerFunc {
 		userID := ctx.Chat().ID
 		bot.RespondSilently(ctx)
 		bot.ItemsService.ClearEditingItemID(userID)
+		bot.ItemsService.ResetPaging(userID)
 		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemsMenuOpenedEvent)
 		return renderItemBox(bot, userID, ctx.Message())
 	}
@@ -163,6 +220,7 @@ func createDeleteItemSelectHandler(bot *b.Bot) telebot.HandlerFunc {
 			return renderItemBox(bot, userID, ctx.Message())
 		}
 
+		name, _ := bot.ItemsService.GetItemNameByID(userID, itemID)
 		if err := bot.ItemsService.DeleteItem(userID, itemID); err != nil {
 			if errors.Is(err, items.ErrItemNotFound) {
 				bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemsMenuOpenedEvent)
@@ -172,37 +230,83 @@ func createDeleteItemSelectHandler(bot *b.Bot) telebot.HandlerFunc {
 		}
 
 		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemsMenuOpenedEvent)
-		return renderItemBox(bot, userID, ctx.Message())
+		note := fmt.Sprintf(bot.Replies.ItemDeletedUndo, html.EscapeString(name), undoWindowMinutes())
+		return renderItemBoxWithUndo(bot, userID, ctx.Message(), note, itemID)
 	}
 }
 
 func renderItemBox(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
+	return renderItemBoxWithNote(bot, userID, sourceMsg, "")
+}
+
+func renderItemBoxWithNote(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	return renderItemBoxWithUndo(bot, userID, sourceMsg, note, 0)
+}
+
+// renderItemBoxWithUndo renders the item box; a non-zero undoItemID adds an undo button for that deletion.
+func renderItemBoxWithUndo(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string, undoItemID uint) error {
 	user := bot.UserService.GetUser(userID)
 	itemList, err := bot.ItemsService.GetItemList(userID)
 	if err != nil {
 		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, itemBoxMarkup())
 	}
-	status := buildItemBoxStatus(bot, user, len(itemList))
+	tagList, err := bot.TagsService.GetList(userID)
+	if err != nil {
+		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, itemBoxMarkup())
+	}
+	now := time.Now()
+	status := buildItemBoxStatus(bot, user, len(itemList), len(itemList)-len(items.ActiveItems(itemList, now)))
+
+	tagNames := make(map[uint]string, len(tagList))
+	for _, tag := range tagList {
+		tagNames[tag.ID] = tag.Name
+	}
+	filterID := bot.TagsService.GetFilter(userID)
+	if filterID != 0 {
+		if name, ok := tagNames[filterID]; ok {
+			status += fmt.Sprintf(bot.Replies.ItemsTagFilterHeader, html.EscapeString(name))
+			itemList = itemsWithTag(itemList, filterID)
+		} else {
+			bot.TagsService.SetFilter(userID, 0)
+			filterID = 0
+		}
+	}
+	grouped := bot.TagsService.IsGrouped(userID)
 
-	var text string
+	var (
+		text                    string
+		start, end, page, pages int
+	)
 	if len(itemList) == 0 {
 		text = fmt.Sprintf(bot.Replies.ItemsMenuEmpty, status)
 	} else {
+		sorted := items.FilterSorted(itemList, "")
+		if grouped {
+			sorted = orderByTag(sorted, tagList)
+		}
+		start, end, page, pages = pageBounds(len(sorted), bot.ItemsService.GetPage(userID), itemBoxPageSize)
 		var builder strings.Builder
 		builder.WriteString(fmt.Sprintf(bot.Replies.ItemsMenuHeader, status))
-		for _, item := range itemList {
-			builder.WriteString(bot.Replies.ItemsMenuItemPrefix)
-			builder.WriteString(item.Name)
-			builder.WriteString("\n")
+		if grouped {
+			writeTagGroupedItems(&builder, bot, sorted[start:end], tagNames, now, safeUserLoc(bot, userID))
+		} else {
+			writeGroupedItems(&builder, bot, sorted[start:end], now, safeUserLoc(bot, userID))
 		}
 		builder.WriteString(bot.Replies.ItemsMenuFooter)
 		text = builder.String()
 	}
+	if note != "" {
+		text = note + "\n\n" + text
+	}
 
-	return upsertBotLastMessage(bot, userID, sourceMsg, text, itemBoxMarkup())
+	markup := itemBoxPagedMarkup(page, pages, grouped, filterID != 0)
+	if undoItemID != 0 {
+		prependUndoRow(markup, btnUndoItemDelete, undoItemID)
+	}
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
 }
 
-func buildItemBoxStatus(bot *b.Bot, user *models.User, itemCount int) string {
+func buildItemBoxStatus(bot *b.Bot, user *models.User, itemCount, pausedCount int) string {
 	if user == nil {
 		user = &models.User{}
 	}
@@ -210,6 +314,9 @@ func buildItemBoxStatus(bot *b.Bot, user *models.User, itemCount int) string {
 	dayStart := helpers.FormatTimeHM(int(user.DayStart))
 	dayEnd := helpers.FormatTimeHM(int(user.DayEnd))
 
+	if pausedCount > 0 {
+		return fmt.Sprintf(bot.Replies.ItemsMenuStatusPaused, mode, itemCount-pausedCount, pausedCount, dayStart, dayEnd)
+	}
 	return fmt.Sprintf(bot.Replies.ItemsMenuStatus, mode, itemCount, dayStart, dayEnd)
 }
 
@@ -222,18 +329,7 @@ func renderAddItemPrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, n
 }
 
 func renderEditItemSelectPrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
-	itemList, err := bot.ItemsService.GetItemList(userID)
-	if err != nil {
-		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, itemBoxMarkup())
-	}
-
-	text := bot.Replies.WhatDoWeEdit
-	if len(itemList) == 0 {
-		text = bot.Replies.ListIsEmpty
-	}
-	return upsertBotLastMessage(bot, userID, sourceMsg, text, selectItemMarkup(itemList, btnSelectItemToEdit, func(item models.Item) string {
-		return fmt.Sprintf("%d", item.ID)
-	}))
+	return renderItemPicker(bot, userID, sourceMsg, bot.Replies.WhatDoWeEdit, btnSelectItemToEdit)
 }
 
 func renderEditItemPrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, itemName string, note string) error {
@@ -257,26 +353,34 @@ func renderEditItemPrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message,
 }
 
 func renderDeleteSelect(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
-	itemList, err := bot.ItemsService.GetItemList(userID)
-	if err != nil {
-		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, itemBoxMarkup())
-	}
-
-	text := bot.Replies.WhatDoWeDelete
-	if len(itemList) == 0 {
-		text = bot.Replies.ListIsEmpty
-	}
-	return upsertBotLastMessage(bot, userID, sourceMsg, text, selectItemMarkup(itemList, btnSelectItemToDelete, func(item models.Item) string {
-		return fmt.Sprintf("%d", item.ID)
-	}))
+	return renderItemPicker(bot, userID, sourceMsg, bot.Replies.WhatDoWeDelete, btnSelectItemToDelete)
 }
 
 func itemBoxMarkup() *telebot.ReplyMarkup {
+	return itemBoxPagedMarkup(0, 1, false, false)
+}
+
+func itemBoxPagedMarkup(page, pages int, grouped, filtered bool) *telebot.ReplyMarkup {
 	markup := &telebot.ReplyMarkup{}
-	markup.Inline(
+	rows := make([]telebot.Row, 0, 6)
+	if pages > 1 {
+		rows = append(rows, pageNavRow(markup, btnItemBoxPage, page, pages))
+	}
+	groupBtn := btnGroupItemsByTag
+	if grouped {
+		groupBtn = btnGroupItemsByName
+	}
+	rows = append(rows,
 		markup.Row(btnAddItem, btnEditItem, btnDeleteItem),
-		markup.Row(btnCloseItemBox),
+		markup.Row(btnPauseItem, btnNoteItem, btnPhrases),
+		markup.Row(btnTags, btnSharedBoxes, btnItemStats),
+		markup.Row(groupBtn, btnSuggestItems),
 	)
+	if filtered {
+		rows = append(rows, markup.Row(btnClearTagFilter))
+	}
+	rows = append(rows, markup.Row(btnCloseItemBox))
+	markup.Inline(rows...)
 	return markup
 }
 
@@ -286,23 +390,6 @@ func backToItemBoxMarkup() *telebot.ReplyMarkup {
 	return markup
 }
 
-func selectItemMarkup(items []models.Item, actionBtn telebot.Btn, data func(models.Item) string) *telebot.ReplyMarkup {
-	markup := &telebot.ReplyMarkup{}
-	if len(items) > 0 {
-		rows := make([]telebot.Row, 0, len(items)+1)
-		for _, item := range items {
-			btn := markup.Data(item.Name, actionBtn.Unique, data(item))
-			rows = append(rows, markup.Row(btn))
-		}
-		rows = append(rows, markup.Row(btnBackToItemBox))
-		markup.Inline(rows...)
-		return markup
-	}
-
-	markup.Inline(markup.Row(btnBackToItemBox))
-	return markup
-}
-
 func upsertBotLastMessage(bot *b.Bot, userID int64, sourceMsg *telebot.Message, text string, markup *telebot.ReplyMarkup) error {
 	msg := sourceMsg
 	if msg == nil {
@@ -0,0 +1,98 @@
+package keyboard
+
+import (
+	"fmt"
+	"io"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/feat/items"
+	"safeboxtgbot/internal/middleware/auth"
+
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	btnItemsImportMerge   = telebot.Btn{Unique: "btn_items_import_merge", Text: "➕ Добавить к текущим"}
+	btnItemsImportReplace = telebot.Btn{Unique: "btn_items_import_replace", Text: "♻️ Заменить всё"}
+	btnItemsImportCancel  = telebot.Btn{Unique: "btn_items_import_cancel", Text: "✖️ Отмена"}
+)
+
+func MustInitItemsImportButtons(bot *b.Bot) {
+	bot.Handle(&btnItemsImportMerge, createItemsImportApplyHandler(bot, false), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnItemsImportReplace, createItemsImportApplyHandler(bot, true), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnItemsImportCancel, createItemsImportCancelHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+// CreateImportItemsHandler parses an uploaded JSON/CSV item file and asks whether to merge or replace.
+func CreateImportItemsHandler(bot *b.Bot, format items.ExportFormat) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		doc := ctx.Message().Document
+		if doc == nil {
+			return nil
+		}
+		if doc.FileSize > constants.MaxItemsImportBytes {
+			return ctx.Send(bot.Replies.ItemsImportTooLarge)
+		}
+
+		reader, err := bot.File(&doc.File)
+		if err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error downloading items file for userID=%d: %v", userID, err))
+			return ctx.Send(bot.Replies.ItemsImportFailed)
+		}
+		defer reader.Close()
+
+		imported, err := items.ParseImport(io.LimitReader(reader, constants.MaxItemsImportBytes), format)
+		if err != nil {
+			bot.Logger.Debug(fmt.Sprintf("Items import parse failed for userID=%d: %v", userID, err))
+			return ctx.Send(bot.Replies.ItemsImportFailed)
+		}
+		if len(imported) == 0 {
+			return ctx.Send(bot.Replies.ItemsImportEmpty)
+		}
+
+		bot.ItemsService.SetPendingImport(userID, imported)
+		return ctx.Send(fmt.Sprintf(bot.Replies.ItemsImportPrompt, len(imported)), itemsImportMarkup())
+	}
+}
+
+func createItemsImportApplyHandler(bot *b.Bot, replace bool) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		imported := bot.ItemsService.GetPendingImport(userID)
+		if len(imported) == 0 {
+			bot.MustEdit(ctx.Message(), bot.Replies.ItemsImportExpired)
+			return nil
+		}
+
+		results, err := bot.ItemsService.ImportItems(userID, imported, replace)
+		if err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error importing items for userID=%d replace=%v: %v", userID, replace, err))
+			bot.MustEdit(ctx.Message(), bot.Replies.Error, itemsImportMarkup())
+			return nil
+		}
+		bot.ItemsService.ClearPendingImport(userID)
+		bot.ItemsService.ClearEditingItemID(userID)
+		return OpenItemBoxWithNote(bot, userID, ctx.Message(), bulkAddReport(bot, results))
+	}
+}
+
+func createItemsImportCancelHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		bot.RespondSilently(ctx)
+		bot.ItemsService.ClearPendingImport(ctx.Chat().ID)
+		bot.MustDelete(ctx.Message())
+		return nil
+	}
+}
+
+func itemsImportMarkup() *telebot.ReplyMarkup {
+	markup := &telebot.ReplyMarkup{}
+	markup.Inline(
+		markup.Row(btnItemsImportMerge),
+		markup.Row(btnItemsImportReplace),
+		markup.Row(btnItemsImportCancel),
+	)
+	return markup
+}
@@ -0,0 +1,134 @@
+package keyboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/feat/items"
+	fsmManager "safeboxtgbot/internal/fsm"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/internal/middleware/auth"
+
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	btnNoteItem         = telebot.Btn{Unique: "btn_note_item", Text: "📝 Заметка"}
+	btnSelectItemToNote = telebot.Btn{Unique: "btn_select_item_to_note"}
+	btnClearItemNote    = telebot.Btn{Unique: "btn_clear_item_note", Text: "🗑 Убрать заметку"}
+	btnBackToNoteSelect = telebot.Btn{Unique: "btn_back_to_note_select", Text: "⬅️ Назад"}
+)
+
+func MustInitItemNoteButtons(bot *b.Bot) {
+	bot.Handle(&btnNoteItem, createNoteItemHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSelectItemToNote, createNoteItemSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnClearItemNote, createClearItemNoteHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnBackToNoteSelect, createBackToNoteSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+func createNoteItemHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemNoteSelectOpenedEvent)
+		bot.ItemsService.ClearEditingItemID(userID)
+		bot.ItemsService.ResetPaging(userID)
+		return renderNoteSelect(bot, userID, ctx.Message())
+	}
+}
+
+func createNoteItemSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		itemID, err := helpers.ParseItemID(ctx)
+		if err != nil {
+			return renderNoteSelect(bot, userID, ctx.Message())
+		}
+		if _, err := bot.ItemsService.GetItemByID(userID, itemID); err != nil {
+			return renderNoteSelect(bot, userID, ctx.Message())
+		}
+
+		bot.ItemsService.SetEditingItemID(userID, itemID)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.AwaitingItemNoteEvent)
+		return renderItemNotePrompt(bot, userID, ctx.Message(), "")
+	}
+}
+
+func CreateValidateItemNoteHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		itemID := bot.ItemsService.GetEditingItemID(userID)
+		bot.MustDelete(ctx.Message())
+		if itemID == 0 {
+			bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemsMenuOpenedEvent)
+			return renderItemBox(bot, userID, nil)
+		}
+		return applyItemNote(bot, userID, nil, itemID, ctx.Message().Text)
+	}
+}
+
+func createClearItemNoteHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		itemID := bot.ItemsService.GetEditingItemID(userID)
+		if itemID == 0 {
+			return renderNoteSelect(bot, userID, ctx.Message())
+		}
+		return applyItemNote(bot, userID, ctx.Message(), itemID, "")
+	}
+}
+
+func createBackToNoteSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ItemsService.ClearEditingItemID(userID)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemNoteSelectOpenedEvent)
+		return renderNoteSelect(bot, userID, ctx.Message())
+	}
+}
+
+func applyItemNote(bot *b.Bot, userID int64, sourceMsg *telebot.Message, itemID uint, note string) error {
+	err := bot.ItemsService.UpdateItemNote(userID, itemID, note)
+	switch {
+	case errors.Is(err, items.ErrItemNoteTooLong):
+		return renderItemNotePrompt(bot, userID, sourceMsg, fmt.Sprintf(bot.Replies.ItemNoteTooLong, constants.MaxItemNoteLen))
+	case err != nil:
+		return handleItemInputError(bot, userID, err, false)
+	}
+
+	bot.ItemsService.ClearEditingItemID(userID)
+	bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemNoteSelectOpenedEvent)
+	return renderNoteSelect(bot, userID, sourceMsg)
+}
+
+func renderNoteSelect(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
+	return renderItemPicker(bot, userID, sourceMsg, bot.Replies.WhatDoWeNote, btnSelectItemToNote)
+}
+
+func renderItemNotePrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	item, err := bot.ItemsService.GetItemByID(userID, bot.ItemsService.GetEditingItemID(userID))
+	if err != nil {
+		bot.ItemsService.ClearEditingItemID(userID)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemNoteSelectOpenedEvent)
+		return renderNoteSelect(bot, userID, sourceMsg)
+	}
+
+	text := fmt.Sprintf(bot.Replies.ItemNotePrompt, html.EscapeString(item.Name), constants.MaxItemNoteLen)
+	markup := &telebot.ReplyMarkup{}
+	if item.Note != "" {
+		text += fmt.Sprintf(bot.Replies.ItemNoteCurrent, html.EscapeString(item.Note))
+		markup.Inline(markup.Row(btnClearItemNote), markup.Row(btnBackToNoteSelect))
+	} else {
+		markup.Inline(markup.Row(btnBackToNoteSelect))
+	}
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
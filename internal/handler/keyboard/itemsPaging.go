@@ -0,0 +1,240 @@
+package keyboard
+
+import (
+	"fmt"
+	"html"
+	b "safeboxtgbot/internal"
+	fsmManager "safeboxtgbot/internal/fsm"
+	"safeboxtgbot/internal/middleware/auth"
+	"safeboxtgbot/models"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/telebot.v4"
+)
+
+const (
+	itemBoxPageSize    = 40
+	itemPickerPageSize = 16
+	itemPickerColumns  = 2
+)
+
+var (
+	btnItemBoxPage     = telebot.Btn{Unique: "btn_item_box_page"}
+	btnItemPickerPage  = telebot.Btn{Unique: "btn_item_picker_page"}
+	btnItemSearchReset = telebot.Btn{Unique: "btn_item_search_reset", Text: "✖️ Сбросить поиск"}
+)
+
+func MustInitItemPagingButtons(bot *b.Bot) {
+	bot.Handle(&btnItemBoxPage, createItemBoxPageHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnItemPickerPage, createItemPickerPageHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnItemSearchReset, createItemSearchResetHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+// CreateItemSearchHandler filters the open edit/delete picker by the typed substring.
+func CreateItemSearchHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.ItemsService.SetQuery(userID, ctx.Message().Text)
+		bot.MustDelete(ctx.Message())
+		return renderCurrentItemPicker(bot, userID, nil)
+	}
+}
+
+func createItemBoxPageHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		if page, err := strconv.Atoi(ctx.Data()); err == nil {
+			bot.ItemsService.SetPage(userID, page)
+		}
+		return renderItemBox(bot, userID, ctx.Message())
+	}
+}
+
+func createItemPickerPageHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		if page, err := strconv.Atoi(ctx.Data()); err == nil {
+			bot.ItemsService.SetPage(userID, page)
+		}
+		return renderCurrentItemPicker(bot, userID, ctx.Message())
+	}
+}
+
+func createItemSearchResetHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ItemsService.SetQuery(userID, "")
+		return renderCurrentItemPicker(bot, userID, ctx.Message())
+	}
+}
+
+// renderCurrentItemPicker re-renders whichever picker the user has open.
+func renderCurrentItemPicker(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
+	switch bot.Fsm.GetFSMForUser(userID).Current() {
+	case fsmManager.StateItemEditSelectOpened:
+		return renderEditItemSelectPrompt(bot, userID, sourceMsg)
+	case fsmManager.StateItemDeleteSelectOpened:
+		return renderDeleteSelect(bot, userID, sourceMsg)
+	case fsmManager.StateItemPauseSelectOpened:
+		return renderPauseSelect(bot, userID, sourceMsg)
+	case fsmManager.StateItemNoteSelectOpened:
+		return renderNoteSelect(bot, userID, sourceMsg)
+	case fsmManager.StateItemStatsSelectOpened:
+		return renderItemStatsSelect(bot, userID, sourceMsg)
+	case fsmManager.StatePhraseSelectOpened:
+		return renderPhraseSelect(bot, userID, sourceMsg)
+	case fsmManager.StateTagAssignOpened:
+		return renderTagAssign(bot, userID, sourceMsg)
+	default:
+		return renderItemBox(bot, userID, sourceMsg)
+	}
+}
+
+func renderItemPicker(bot *b.Bot, userID int64, sourceMsg *telebot.Message, title string, actionBtn telebot.Btn) error {
+	return renderItemPickerWith(bot, userID, sourceMsg, title, actionBtn, btnBackToItemBox, nil)
+}
+
+// renderItemPickerWith is renderItemPicker with a custom back button and an optional extra label mark.
+func renderItemPickerWith(
+	bot *b.Bot,
+	userID int64,
+	sourceMsg *telebot.Message,
+	title string,
+	actionBtn, backBtn telebot.Btn,
+	mark func(models.Item) string,
+) error {
+	query := bot.ItemsService.GetQuery(userID)
+	itemList, err := bot.ItemsService.Search(userID, query)
+	if err != nil {
+		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, itemBoxMarkup())
+	}
+
+	text := title + bot.Replies.ItemsPickerSearchHint
+	switch {
+	case query != "" && len(itemList) == 0:
+		text = fmt.Sprintf(bot.Replies.ItemsSearchNothing, html.EscapeString(query)) + "\n\n" + text
+	case query != "":
+		text = fmt.Sprintf(bot.Replies.ItemsSearchResult, html.EscapeString(query), len(itemList)) + "\n\n" + text
+	case len(itemList) == 0:
+		text = bot.Replies.ListIsEmpty
+	}
+
+	start, end, page, pages := pageBounds(len(itemList), bot.ItemsService.GetPage(userID), itemPickerPageSize)
+	now, loc := time.Now(), safeUserLoc(bot, userID)
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, itemPickerPageSize/itemPickerColumns+3)
+	row := make([]telebot.Btn, 0, itemPickerColumns)
+	for _, item := range itemList[start:end] {
+		label := item.Name + pausedItemMark(bot, item, now, loc)
+		if item.Note != "" {
+			label += bot.Replies.ItemNoteMark
+		}
+		if mark != nil {
+			label += mark(item)
+		}
+		row = append(row, markup.Data(label, actionBtn.Unique, strconv.FormatUint(uint64(item.ID), 10)))
+		if len(row) == itemPickerColumns {
+			rows = append(rows, markup.Row(row...))
+			row = make([]telebot.Btn, 0, itemPickerColumns)
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, markup.Row(row...))
+	}
+	if pages > 1 {
+		rows = append(rows, pageNavRow(markup, btnItemPickerPage, page, pages))
+	}
+	if query != "" {
+		rows = append(rows, markup.Row(btnItemSearchReset))
+	}
+	rows = append(rows, markup.Row(backBtn))
+	markup.Inline(rows...)
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+// pageBounds clamps page into range and returns the slice bounds for it.
+func pageBounds(total, page, size int) (start, end, current, pages int) {
+	pages = (total + size - 1) / size
+	if pages < 1 {
+		pages = 1
+	}
+	current = min(max(page, 0), pages-1)
+	start = current * size
+	end = min(start+size, total)
+	return start, end, current, pages
+}
+
+func pageNavRow(markup *telebot.ReplyMarkup, navBtn telebot.Btn, page, pages int) telebot.Row {
+	prev := noopBtn(markup, " ")
+	if page > 0 {
+		prev = markup.Data("◀️", navBtn.Unique, strconv.Itoa(page-1))
+	}
+	next := noopBtn(markup, " ")
+	if page < pages-1 {
+		next = markup.Data("▶️", navBtn.Unique, strconv.Itoa(page+1))
+	}
+	return markup.Row(prev, noopBtn(markup, fmt.Sprintf("%d/%d", page+1, pages)), next)
+}
+
+// writeGroupedItems lists sorted items under first-letter headers.
+func writeGroupedItems(builder *strings.Builder, bot *b.Bot, itemList []models.Item, now time.Time, loc *time.Location) {
+	var group rune
+	for _, item := range itemList {
+		first, _ := utf8.DecodeRuneInString(item.Name)
+		first = unicode.ToUpper(first)
+		if first != group {
+			if group != 0 {
+				builder.WriteString("\n")
+			}
+			group = first
+			builder.WriteString(fmt.Sprintf(bot.Replies.ItemsMenuGroupHeader, string(first)))
+		}
+		writeItemLine(builder, bot, item, now, loc)
+	}
+}
+
+// writeTagGroupedItems lists items ordered by tags.GroupByTag under tag-name headers.
+func writeTagGroupedItems(builder *strings.Builder, bot *b.Bot, itemList []models.Item, tagNames map[uint]string, now time.Time, loc *time.Location) {
+	for i, item := range itemList {
+		key := itemTagKey(item, tagNames)
+		if i == 0 || key != itemTagKey(itemList[i-1], tagNames) {
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			header := bot.Replies.ItemsUntaggedHeader
+			if key != 0 {
+				header = "🏷 " + html.EscapeString(tagNames[key])
+			}
+			builder.WriteString(fmt.Sprintf(bot.Replies.ItemsMenuGroupHeader, header))
+		}
+		writeItemLine(builder, bot, item, now, loc)
+	}
+}
+
+func writeItemLine(builder *strings.Builder, bot *b.Bot, item models.Item, now time.Time, loc *time.Location) {
+	builder.WriteString(bot.Replies.ItemsMenuItemPrefix)
+	builder.WriteString(item.Name)
+	builder.WriteString(pausedItemMark(bot, item, now, loc))
+	if item.Note != "" {
+		builder.WriteString(bot.Replies.ItemNoteMark)
+	}
+	builder.WriteString("\n")
+}
+
+// itemTagKey is the item's tag ID, or 0 when it is untagged or the tag is gone.
+func itemTagKey(item models.Item, tagNames map[uint]string) uint {
+	if item.TagID == nil {
+		return 0
+	}
+	if _, ok := tagNames[*item.TagID]; !ok {
+		return 0
+	}
+	return *item.TagID
+}
@@ -0,0 +1,219 @@
+package keyboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/feat/items"
+	fsmManager "safeboxtgbot/internal/fsm"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/internal/middleware/auth"
+	"safeboxtgbot/models"
+	"strconv"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	btnPauseItem            = telebot.Btn{Unique: "btn_pause_item", Text: "⏸ Пауза"}
+	btnSelectItemToPause    = telebot.Btn{Unique: "btn_select_item_to_pause"}
+	btnItemPauseFor         = telebot.Btn{Unique: "btn_item_pause_for"}
+	btnItemPauseUntilDate   = telebot.Btn{Unique: "btn_item_pause_until_date", Text: "📅 До даты"}
+	btnItemPauseCalendarDay = telebot.Btn{Unique: "btn_item_pause_calendar_day"}
+	btnItemPauseCalendarNav = telebot.Btn{Unique: "btn_item_pause_calendar_nav"}
+	btnBackToPauseSelect    = telebot.Btn{Unique: "btn_back_to_pause_select", Text: "⬅️ Назад"}
+)
+
+// itemPauseDays are the quick options; 0 pauses until resumed manually.
+var itemPauseDays = []int{1, 3, 7, 0}
+
+func MustInitItemPauseButtons(bot *b.Bot) {
+	bot.Handle(&btnPauseItem, createPauseItemHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSelectItemToPause, createPauseItemSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnItemPauseFor, createItemPauseForHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnItemPauseUntilDate, createItemPauseUntilDateHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnItemPauseCalendarDay, createItemPauseCalendarDayHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnItemPauseCalendarNav, createItemPauseCalendarNavHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnBackToPauseSelect, createBackToPauseSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+func createPauseItemHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemPauseSelectOpenedEvent)
+		bot.ItemsService.ClearEditingItemID(userID)
+		bot.ItemsService.ResetPaging(userID)
+		return renderPauseSelect(bot, userID, ctx.Message())
+	}
+}
+
+// createPauseItemSelectHandler resumes a paused item right away, or asks for how long to pause an active one.
+func createPauseItemSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		itemID, err := helpers.ParseItemID(ctx)
+		if err != nil {
+			return renderPauseSelect(bot, userID, ctx.Message())
+		}
+		item, err := bot.ItemsService.GetItemByID(userID, itemID)
+		if err != nil {
+			return renderPauseSelect(bot, userID, ctx.Message())
+		}
+
+		if items.IsPaused(*item, time.Now()) {
+			if err := bot.ItemsService.Resume(userID, itemID); err != nil {
+				return handleItemPauseError(bot, userID, ctx.Message(), err)
+			}
+			return renderPauseSelect(bot, userID, ctx.Message())
+		}
+
+		bot.ItemsService.SetEditingItemID(userID, itemID)
+		return renderPauseOptions(bot, userID, ctx.Message(), item.Name)
+	}
+}
+
+func createItemPauseForHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		days, err := strconv.Atoi(ctx.Data())
+		if err != nil || days < 0 {
+			return renderPauseSelect(bot, userID, ctx.Message())
+		}
+
+		var until *time.Time
+		if days > 0 {
+			resumeAt := startOfLocalDay(time.Now().In(safeUserLoc(bot, userID))).AddDate(0, 0, days)
+			until = &resumeAt
+		}
+		return applyItemPause(bot, userID, ctx.Message(), until)
+	}
+}
+
+func createItemPauseUntilDateHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		now := time.Now().In(safeUserLoc(bot, userID))
+		return renderItemPauseCalendar(bot, userID, ctx.Message(), now)
+	}
+}
+
+func createItemPauseCalendarNavHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		month, err := parseCalendarMonth(ctx.Data(), safeUserLoc(bot, userID))
+		if err != nil {
+			return renderPauseSelect(bot, userID, ctx.Message())
+		}
+		return renderItemPauseCalendar(bot, userID, ctx.Message(), month)
+	}
+}
+
+func createItemPauseCalendarDayHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		day, err := parseCalendarDay(ctx.Data(), safeUserLoc(bot, userID))
+		if err != nil {
+			return renderPauseSelect(bot, userID, ctx.Message())
+		}
+		return applyItemPause(bot, userID, ctx.Message(), &day)
+	}
+}
+
+func createBackToPauseSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ItemsService.ClearEditingItemID(userID)
+		return renderPauseSelect(bot, userID, ctx.Message())
+	}
+}
+
+func applyItemPause(bot *b.Bot, userID int64, sourceMsg *telebot.Message, until *time.Time) error {
+	itemID := bot.ItemsService.GetEditingItemID(userID)
+	if itemID == 0 {
+		return renderPauseSelect(bot, userID, sourceMsg)
+	}
+	if err := bot.ItemsService.Pause(userID, itemID, until); err != nil {
+		return handleItemPauseError(bot, userID, sourceMsg, err)
+	}
+	bot.ItemsService.ClearEditingItemID(userID)
+	return renderPauseSelect(bot, userID, sourceMsg)
+}
+
+func renderPauseSelect(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
+	return renderItemPicker(bot, userID, sourceMsg, bot.Replies.WhatDoWePause, btnSelectItemToPause)
+}
+
+func renderPauseOptions(bot *b.Bot, userID int64, sourceMsg *telebot.Message, itemName string) error {
+	markup := &telebot.ReplyMarkup{}
+	row := make([]telebot.Btn, 0, len(itemPauseDays))
+	for _, days := range itemPauseDays {
+		row = append(row, markup.Data(pauseOptionLabel(bot, days), btnItemPauseFor.Unique, strconv.Itoa(days)))
+	}
+	markup.Inline(
+		markup.Row(row...),
+		markup.Row(btnItemPauseUntilDate),
+		markup.Row(btnBackToPauseSelect),
+	)
+	text := fmt.Sprintf(bot.Replies.ItemPausePrompt, html.EscapeString(itemName))
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func renderItemPauseCalendar(bot *b.Bot, userID int64, sourceMsg *telebot.Message, month time.Time) error {
+	itemID := bot.ItemsService.GetEditingItemID(userID)
+	itemName, err := bot.ItemsService.GetItemNameByID(userID, itemID)
+	if err != nil {
+		return renderPauseSelect(bot, userID, sourceMsg)
+	}
+	loc := safeUserLoc(bot, userID)
+	tomorrow := startOfLocalDay(time.Now().In(loc)).AddDate(0, 0, 1)
+	footer := (&telebot.ReplyMarkup{}).Row(btnBackToPauseSelect)
+	markup := calendarMarkup(month, tomorrow, btnItemPauseCalendarDay, btnItemPauseCalendarNav, footer)
+	text := fmt.Sprintf(bot.Replies.ItemPauseDatePrompt, html.EscapeString(itemName))
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func pauseOptionLabel(bot *b.Bot, days int) string {
+	switch days {
+	case 0:
+		return bot.Replies.ItemPauseForever
+	case 1:
+		return bot.Replies.ItemPauseTomorrow
+	default:
+		return fmt.Sprintf(bot.Replies.ItemPauseDays, days)
+	}
+}
+
+// pausedItemMark is appended to paused item names in the box and pickers.
+func pausedItemMark(bot *b.Bot, item models.Item, now time.Time, loc *time.Location) string {
+	if !items.IsPaused(item, now) {
+		return ""
+	}
+	if item.PausedUntil == nil {
+		return bot.Replies.ItemPausedMark
+	}
+	return fmt.Sprintf(bot.Replies.ItemPausedUntilMark, item.PausedUntil.In(loc).Format("02.01"))
+}
+
+func startOfLocalDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func handleItemPauseError(bot *b.Bot, userID int64, sourceMsg *telebot.Message, err error) error {
+	if errors.Is(err, items.ErrItemNotFound) {
+		bot.ItemsService.ClearEditingItemID(userID)
+		return renderPauseSelect(bot, userID, sourceMsg)
+	}
+	bot.Logger.Error(fmt.Sprintf("Error pausing item for userID=%d: %v", userID, err))
+	bot.ItemsService.ClearEditingItemID(userID)
+	return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, itemBoxMarkup())
+}
@@ -0,0 +1,213 @@
+package keyboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/feat/items"
+	fsmManager "safeboxtgbot/internal/fsm"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/internal/middleware/auth"
+	"safeboxtgbot/models"
+	"strconv"
+	"strings"
+
+	"gopkg.in/telebot.v4"
+)
+
+// phraseDeleteButtonsPerRow keeps the numbered delete buttons readable on narrow screens.
+const phraseDeleteButtonsPerRow = 5
+
+var (
+	btnPhrases            = telebot.Btn{Unique: "btn_phrases", Text: "💬 Фразы"}
+	btnSelectItemPhrases  = telebot.Btn{Unique: "btn_select_item_phrases"}
+	btnDeletePhrase       = telebot.Btn{Unique: "btn_delete_phrase"}
+	btnSetPhraseShare     = telebot.Btn{Unique: "btn_set_phrase_share"}
+	btnBackToPhraseSelect = telebot.Btn{Unique: "btn_back_to_phrase_select", Text: "⬅️ Назад"}
+)
+
+func MustInitItemPhraseButtons(bot *b.Bot) {
+	bot.Handle(&btnPhrases, createPhrasesHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSelectItemPhrases, createPhrasesSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnDeletePhrase, createDeletePhraseHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSetPhraseShare, createSetPhraseShareHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnBackToPhraseSelect, createBackToPhraseSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+func createPhrasesHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.PhraseSelectOpenedEvent)
+		bot.ItemsService.ClearEditingItemID(userID)
+		bot.ItemsService.ResetPaging(userID)
+		return renderPhraseSelect(bot, userID, ctx.Message())
+	}
+}
+
+func createPhrasesSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		itemID, err := helpers.ParseItemID(ctx)
+		if err != nil {
+			return renderPhraseSelect(bot, userID, ctx.Message())
+		}
+		if _, err := bot.ItemsService.GetItemByID(userID, itemID); err != nil {
+			return renderPhraseSelect(bot, userID, ctx.Message())
+		}
+
+		bot.ItemsService.SetEditingItemID(userID, itemID)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.AwaitingPhraseAddEvent)
+		return renderItemPhrases(bot, userID, ctx.Message(), "")
+	}
+}
+
+func CreateValidateAddPhraseHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		itemID := bot.ItemsService.GetEditingItemID(userID)
+		bot.MustDelete(ctx.Message())
+		if itemID == 0 {
+			bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemsMenuOpenedEvent)
+			return renderItemBox(bot, userID, nil)
+		}
+
+		err := bot.ItemsService.AddPhrase(userID, itemID, ctx.Message().Text)
+		switch {
+		case errors.Is(err, items.ErrPhraseEmpty):
+			return renderItemPhrases(bot, userID, nil, bot.Replies.PhraseEmpty)
+		case errors.Is(err, items.ErrPhraseTooLong):
+			return renderItemPhrases(bot, userID, nil, fmt.Sprintf(bot.Replies.PhraseTooLong, constants.MaxPhraseLen))
+		case errors.Is(err, items.ErrPhraseDuplicate):
+			return renderItemPhrases(bot, userID, nil, bot.Replies.PhraseDuplicate)
+		case errors.Is(err, items.ErrPhraseLimitReached):
+			return renderItemPhrases(bot, userID, nil, fmt.Sprintf(bot.Replies.PhraseLimitReached, constants.MaxPhrasesPerItem))
+		case err != nil:
+			return handleItemInputError(bot, userID, err, false)
+		}
+		return renderItemPhrases(bot, userID, nil, bot.Replies.PhraseAdded)
+	}
+}
+
+func createDeletePhraseHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		itemID := bot.ItemsService.GetEditingItemID(userID)
+		phraseID, err := strconv.ParseUint(ctx.Data(), 10, 64)
+		if itemID == 0 || err != nil {
+			return renderItemPhrases(bot, userID, ctx.Message(), "")
+		}
+
+		err = bot.ItemsService.DeletePhrase(userID, itemID, uint(phraseID))
+		switch {
+		case errors.Is(err, items.ErrPhraseNotFound):
+			return renderItemPhrases(bot, userID, ctx.Message(), "")
+		case err != nil:
+			return handleItemInputError(bot, userID, err, false)
+		}
+		return renderItemPhrases(bot, userID, ctx.Message(), bot.Replies.PhraseDeleted)
+	}
+}
+
+func createSetPhraseShareHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		itemID := bot.ItemsService.GetEditingItemID(userID)
+		share, err := strconv.ParseInt(ctx.Data(), 10, 16)
+		if itemID == 0 || err != nil {
+			return renderItemPhrases(bot, userID, ctx.Message(), "")
+		}
+
+		err = bot.ItemsService.SetPhraseShare(userID, itemID, int16(share))
+		switch {
+		case errors.Is(err, items.ErrPhraseShareInvalid):
+			return renderItemPhrases(bot, userID, ctx.Message(), "")
+		case err != nil:
+			return handleItemInputError(bot, userID, err, false)
+		}
+		return renderItemPhrases(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createBackToPhraseSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ItemsService.ClearEditingItemID(userID)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.PhraseSelectOpenedEvent)
+		return renderPhraseSelect(bot, userID, ctx.Message())
+	}
+}
+
+// renderPhraseSelect is the item picker with the phrase share next to items that use phrases.
+func renderPhraseSelect(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
+	mark := func(item models.Item) string {
+		if item.PhraseShare == 0 {
+			return ""
+		}
+		return fmt.Sprintf(bot.Replies.PhraseShareMark, item.PhraseShare)
+	}
+	return renderItemPickerWith(bot, userID, sourceMsg, bot.Replies.WhatDoWePhrase, btnSelectItemPhrases, btnBackToItemBox, mark)
+}
+
+// renderItemPhrases shows the phrase pool of the edited item; any text typed meanwhile is added to it.
+func renderItemPhrases(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	item, err := bot.ItemsService.GetItemByID(userID, bot.ItemsService.GetEditingItemID(userID))
+	if err != nil {
+		bot.ItemsService.ClearEditingItemID(userID)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.PhraseSelectOpenedEvent)
+		return renderPhraseSelect(bot, userID, sourceMsg)
+	}
+	phrases, err := bot.ItemsService.Phrases(userID, item.ID)
+	if err != nil {
+		bot.Logger.Error(fmt.Sprintf("Error loading phrases for userID=%d itemID=%d: %v", userID, item.ID, err))
+		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, itemBoxMarkup())
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(bot.Replies.PhrasesHeader, html.EscapeString(item.Name), constants.MaxPhraseLen))
+	if len(phrases) == 0 {
+		builder.WriteString(bot.Replies.PhrasesEmpty)
+	}
+	for i, phrase := range phrases {
+		builder.WriteString(fmt.Sprintf(bot.Replies.PhraseRow, i+1, html.EscapeString(phrase.Text)))
+	}
+	builder.WriteString(fmt.Sprintf(bot.Replies.PhraseShareCurrent, item.PhraseShare))
+	text := builder.String()
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, len(phrases)/phraseDeleteButtonsPerRow+3)
+	shareRow := make(telebot.Row, 0, len(items.PhraseShareSteps))
+	for _, step := range items.PhraseShareSteps {
+		label := fmt.Sprintf("%d%%", step)
+		if step == item.PhraseShare {
+			label = "✓ " + label
+		}
+		shareRow = append(shareRow, markup.Data(label, btnSetPhraseShare.Unique, strconv.Itoa(int(step))))
+	}
+	rows = append(rows, shareRow)
+
+	var deleteRow telebot.Row
+	for i, phrase := range phrases {
+		deleteRow = append(deleteRow, markup.Data(fmt.Sprintf("🗑 %d", i+1), btnDeletePhrase.Unique, strconv.FormatUint(uint64(phrase.ID), 10)))
+		if len(deleteRow) == phraseDeleteButtonsPerRow {
+			rows = append(rows, deleteRow)
+			deleteRow = nil
+		}
+	}
+	if len(deleteRow) > 0 {
+		rows = append(rows, deleteRow)
+	}
+	rows = append(rows, markup.Row(btnBackToPhraseSelect))
+	markup.Inline(rows...)
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
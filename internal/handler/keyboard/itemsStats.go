@@ -0,0 +1,153 @@
+package keyboard
+
+import (
+	"context"
+	"fmt"
+	"html"
+	b "safeboxtgbot/internal"
+	fsmManager "safeboxtgbot/internal/fsm"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/internal/middleware/auth"
+	"safeboxtgbot/models"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+// itemStatsRecentTexts is how many stored nudge texts the drill-down shows.
+const itemStatsRecentTexts = 5
+
+var (
+	btnItemStats         = telebot.Btn{Unique: "btn_item_stats", Text: "📊 Статистика"}
+	btnSelectItemStats   = telebot.Btn{Unique: "btn_select_item_stats"}
+	btnItemStatsTexts    = telebot.Btn{Unique: "btn_item_stats_texts", Text: "💬 Последние 5 текстов"}
+	btnBackToItemStats   = telebot.Btn{Unique: "btn_back_to_item_stats", Text: "⬅️ Назад"}
+	btnBackToStatsSelect = telebot.Btn{Unique: "btn_back_to_stats_select", Text: "⬅️ Назад"}
+)
+
+func MustInitItemStatsButtons(bot *b.Bot) {
+	bot.Handle(&btnItemStats, createItemStatsHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSelectItemStats, createItemStatsSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnItemStatsTexts, createItemStatsTextsHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnBackToItemStats, createBackToItemStatsHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnBackToStatsSelect, createBackToStatsSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+func createItemStatsHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemStatsSelectOpenedEvent)
+		bot.ItemsService.ClearEditingItemID(userID)
+		bot.ItemsService.ResetPaging(userID)
+		return renderItemStatsSelect(bot, userID, ctx.Message())
+	}
+}
+
+func createItemStatsSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		itemID, err := helpers.ParseItemID(ctx)
+		if err != nil {
+			return renderItemStatsSelect(bot, userID, ctx.Message())
+		}
+		bot.ItemsService.SetEditingItemID(userID, itemID)
+		return renderItemStats(bot, userID, ctx.Message(), false)
+	}
+}
+
+func createItemStatsTextsHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		return renderItemStats(bot, userID, ctx.Message(), true)
+	}
+}
+
+func createBackToItemStatsHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		return renderItemStats(bot, userID, ctx.Message(), false)
+	}
+}
+
+func createBackToStatsSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ItemsService.ClearEditingItemID(userID)
+		return renderItemStatsSelect(bot, userID, ctx.Message())
+	}
+}
+
+// renderItemStatsSelect is the item picker with the nudge count next to every item.
+func renderItemStatsSelect(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
+	stats, err := bot.ItemStatsService.ByItem(userID)
+	if err != nil {
+		bot.Logger.Error(fmt.Sprintf("Error loading item stats for userID=%d: %v", userID, err))
+		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, itemBoxMarkup())
+	}
+
+	total := 0
+	for _, s := range stats {
+		total += s.Count
+	}
+	title := bot.Replies.ItemStatsEmpty
+	if total > 0 {
+		title = fmt.Sprintf(bot.Replies.ItemStatsSelect, total)
+	}
+	mark := func(item models.Item) string {
+		return fmt.Sprintf(bot.Replies.ItemStatsMark, stats[item.ID].Count)
+	}
+	return renderItemPickerWith(bot, userID, sourceMsg, title, btnSelectItemStats, btnBackToItemBox, mark)
+}
+
+// renderItemStats shows the summary of the selected item, or its latest texts when withTexts is set.
+func renderItemStats(bot *b.Bot, userID int64, sourceMsg *telebot.Message, withTexts bool) error {
+	item, err := bot.ItemsService.GetItemByID(userID, bot.ItemsService.GetEditingItemID(userID))
+	if err != nil {
+		bot.ItemsService.ClearEditingItemID(userID)
+		return renderItemStatsSelect(bot, userID, sourceMsg)
+	}
+	stats, recent, err := bot.ItemStatsService.ForItem(userID, item.ID, itemStatsRecentTexts)
+	if err != nil {
+		bot.Logger.Error(fmt.Sprintf("Error loading stats for userID=%d itemID=%d: %v", userID, item.ID, err))
+		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, itemBoxMarkup())
+	}
+
+	loc := safeUserLoc(bot, userID)
+	name := html.EscapeString(item.Name)
+	markup := &telebot.ReplyMarkup{}
+	if withTexts {
+		markup.Inline(markup.Row(btnBackToItemStats))
+		if len(recent) == 0 {
+			return upsertBotLastMessage(bot, userID, sourceMsg, fmt.Sprintf(bot.Replies.ItemStatsTextsEmpty, name), markup)
+		}
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf(bot.Replies.ItemStatsTextsHeader, name))
+		for _, log := range recent {
+			builder.WriteString(fmt.Sprintf(bot.Replies.ItemStatsTextRow, formatStatsTime(log.SentAt, loc), html.EscapeString(log.Text)))
+		}
+		return upsertBotLastMessage(bot, userID, sourceMsg, strings.TrimRight(builder.String(), "\n"), markup)
+	}
+
+	text := fmt.Sprintf(bot.Replies.ItemStatsDetail, name, stats.Count)
+	if stats.Count == 0 {
+		text += bot.Replies.ItemStatsNever
+		markup.Inline(markup.Row(btnBackToStatsSelect))
+		return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+	}
+	text += fmt.Sprintf(bot.Replies.ItemStatsLastSent, formatStatsTime(stats.LastSentAt, loc))
+	if stats.AvgGap > 0 {
+		text += fmt.Sprintf(bot.Replies.ItemStatsAvgGap, helpers.FormatGap(stats.AvgGap))
+	}
+	markup.Inline(markup.Row(btnItemStatsTexts), markup.Row(btnBackToStatsSelect))
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func formatStatsTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("02.01.2006 15:04")
+}
@@ -0,0 +1,150 @@
+package keyboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/feat/items"
+	"safeboxtgbot/internal/feat/prompt"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/internal/middleware/auth"
+	"strconv"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+const itemSuggestTimeout = 45 * time.Second
+
+var (
+	btnSuggestItems     = telebot.Btn{Unique: "btn_suggest_items", Text: "💡 Идеи"}
+	btnToggleSuggestion = telebot.Btn{Unique: "btn_toggle_suggestion"}
+	btnMoreSuggestions  = telebot.Btn{Unique: "btn_more_suggestions", Text: "🔄 Ещё идеи"}
+)
+
+// MustInitItemSuggestButtons is registered after the LLM stack is built, like the admin commands.
+func MustInitItemSuggestButtons(bot *b.Bot, suggester *prompt.ItemSuggester) {
+	bot.Handle(&btnSuggestItems, createSuggestItemsHandler(bot, suggester), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnMoreSuggestions, createSuggestItemsHandler(bot, suggester), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnToggleSuggestion, createToggleSuggestionHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+func createSuggestItemsHandler(bot *b.Bot, suggester *prompt.ItemSuggester) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ItemsService.ClearEditingItemID(userID)
+		itemList, err := bot.ItemsService.GetItemList(userID)
+		if err != nil {
+			return upsertBotLastMessage(bot, userID, ctx.Message(), bot.Replies.Error, itemBoxMarkup())
+		}
+		_ = upsertBotLastMessage(bot, userID, ctx.Message(), bot.Replies.ItemSuggestThinking, backToItemBoxMarkup())
+
+		names := make([]string, 0, len(itemList))
+		for _, item := range itemList {
+			names = append(names, item.Name)
+		}
+		user := bot.UserService.GetUser(userID)
+		loc, _ := helpers.UserLocation(*user)
+		mode := helpers.ModeToStyle(helpers.ModeAt(*user, time.Now().In(loc)))
+		requestCtx, cancel := context.WithTimeout(prompt.WithUserID(context.Background(), userID), itemSuggestTimeout)
+		defer cancel()
+		ideas, err := suggester.Suggest(requestCtx, prompt.SuggestInput{
+			CurrentItems: names,
+			StyleMode:    mode,
+			Count:        constants.ItemSuggestionsCount,
+		})
+		if errors.Is(err, prompt.ErrBudgetExhausted) {
+			bot.Logger.Debug(fmt.Sprintf("Item suggestions skipped for userID=%d: %v", userID, err))
+			return upsertBotLastMessage(bot, userID, ctx.Message(), bot.Replies.ItemSuggestFailed, suggestFailedMarkup())
+		}
+		if err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error suggesting items for userID=%d: %v", userID, err))
+			return upsertBotLastMessage(bot, userID, ctx.Message(), bot.Replies.ItemSuggestFailed, suggestFailedMarkup())
+		}
+
+		bot.ItemsService.SetSuggestions(userID, ideas)
+		return renderSuggestions(bot, userID, ctx.Message(), "")
+	}
+}
+
+// createToggleSuggestionHandler adds the idea as an item, or removes it if it was already added.
+func createToggleSuggestionHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		ideas := bot.ItemsService.GetSuggestions(userID)
+		index, err := strconv.Atoi(ctx.Data())
+		if err != nil || index < 0 || index >= len(ideas) {
+			return renderSuggestions(bot, userID, ctx.Message(), "")
+		}
+
+		name := ideas[index]
+		if itemID, err := bot.ItemsService.GetItemIDByName(userID, name); err == nil {
+			if err := bot.ItemsService.DeleteItem(userID, itemID); err != nil && !errors.Is(err, items.ErrItemNotFound) {
+				return upsertBotLastMessage(bot, userID, ctx.Message(), bot.Replies.Error, itemBoxMarkup())
+			}
+			return renderSuggestions(bot, userID, ctx.Message(), "")
+		}
+
+		err = bot.ItemsService.CreateItem(userID, name)
+		switch {
+		case errors.Is(err, items.ErrItemLimitReached):
+			return renderSuggestions(bot, userID, ctx.Message(), bot.Replies.ItemsLimitReached)
+		case errors.Is(err, items.ErrItemDuplicate), err == nil:
+			return renderSuggestions(bot, userID, ctx.Message(), "")
+		default:
+			bot.Logger.Error(fmt.Sprintf("Error adding suggested item for userID=%d: %v", userID, err))
+			return upsertBotLastMessage(bot, userID, ctx.Message(), bot.Replies.Error, itemBoxMarkup())
+		}
+	}
+}
+
+func renderSuggestions(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	ideas := bot.ItemsService.GetSuggestions(userID)
+	if len(ideas) == 0 {
+		return renderItemBox(bot, userID, sourceMsg)
+	}
+	itemList, err := bot.ItemsService.GetItemList(userID)
+	if err != nil {
+		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, itemBoxMarkup())
+	}
+	added := make(map[string]struct{}, len(itemList))
+	for _, item := range itemList {
+		added[item.Name] = struct{}{}
+	}
+
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, len(ideas)/itemPickerColumns+3)
+	row := make([]telebot.Btn, 0, itemPickerColumns)
+	for i, idea := range ideas {
+		label := "➕ " + idea
+		if _, ok := added[idea]; ok {
+			label = "✅ " + idea
+		}
+		row = append(row, markup.Data(label, btnToggleSuggestion.Unique, strconv.Itoa(i)))
+		if len(row) == itemPickerColumns {
+			rows = append(rows, markup.Row(row...))
+			row = make([]telebot.Btn, 0, itemPickerColumns)
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, markup.Row(row...))
+	}
+	rows = append(rows, markup.Row(btnMoreSuggestions), markup.Row(btnBackToItemBox))
+	markup.Inline(rows...)
+
+	text := bot.Replies.ItemSuggestHeader
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func suggestFailedMarkup() *telebot.ReplyMarkup {
+	markup := &telebot.ReplyMarkup{}
+	markup.Inline(markup.Row(btnMoreSuggestions), markup.Row(btnBackToItemBox))
+	return markup
+}
@@ -11,8 +11,19 @@ import (
 func MustInitKeyboardHandler(bot *b.Bot) {
 	bot.Handle(OpenItemBoxLabel, createOpenItemBoxBtnHandler(bot), auth.CreateAuthMiddleware(bot))
 	bot.Handle(OpenReminderBoxLabel, createOpenReminderBoxBtnHandler(bot), auth.CreateAuthMiddleware(bot))
+	MustInitPickerButtons(bot)
 	MustInitItemBoxButtons(bot)
+	MustInitItemsImportButtons(bot)
+	MustInitItemPagingButtons(bot)
+	MustInitItemPauseButtons(bot)
+	MustInitItemNoteButtons(bot)
+	MustInitItemPhraseButtons(bot)
+	MustInitTagButtons(bot)
+	MustInitItemStatsButtons(bot)
+	MustInitSharedBoxButtons(bot)
 	MustInitReminderBoxButtons(bot)
+	MustInitExclusionButtons(bot)
+	MustInitUndoButtons(bot)
 }
 
 func createOpenItemBoxBtnHandler(bot *b.Bot) telebot.HandlerFunc {
@@ -32,6 +32,13 @@ var (
 	btnReminderInterval = telebot.Btn{Unique: "btn_reminder_interval", Text: "Через интервал"}
 	btnReminderOnce     = telebot.Btn{Unique: "btn_reminder_once", Text: "Один раз"}
 	btnSelectWeekday    = telebot.Btn{Unique: "btn_select_weekday"}
+	btnSelectMonthDay   = telebot.Btn{Unique: "btn_select_month_day"}
+
+	btnReminderCalendarDay = telebot.Btn{Unique: "btn_reminder_calendar_day"}
+	btnReminderCalendarNav = telebot.Btn{Unique: "btn_reminder_calendar_nav"}
+	btnReminderHour        = telebot.Btn{Unique: "btn_reminder_hour"}
+	btnReminderMinute      = telebot.Btn{Unique: "btn_reminder_minute"}
+	btnReminderHoursBack   = telebot.Btn{Unique: "btn_reminder_hours_back", Text: "⬅️ Часы"}
 )
 
 func MustInitReminderBoxButtons(bot *b.Bot) {
@@ -47,6 +54,13 @@ func MustInitReminderBoxButtons(bot *b.Bot) {
 	bot.Handle(&btnReminderInterval, createSelectScheduleHandler(bot, models.ReminderScheduleInterval), auth.CreateAuthMiddleware(bot))
 	bot.Handle(&btnReminderOnce, createSelectScheduleHandler(bot, models.ReminderScheduleOnce), auth.CreateAuthMiddleware(bot))
 	bot.Handle(&btnSelectWeekday, createSelectWeekdayHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSelectMonthDay, createSelectMonthDayHandler(bot), auth.CreateAuthMiddleware(bot))
+
+	bot.Handle(&btnReminderCalendarDay, createCalendarDayHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnReminderCalendarNav, createCalendarNavHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnReminderHour, createHourSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnReminderMinute, createMinuteSelectHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnReminderHoursBack, createHoursBackHandler(bot), auth.CreateAuthMiddleware(bot))
 }
 
 func OpenReminderBox(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
@@ -224,6 +238,102 @@ func createSelectWeekdayHandler(bot *b.Bot) telebot.HandlerFunc {
 	}
 }
 
+func createSelectMonthDayHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		pending := bot.ReminderService.GetPending(userID)
+		if pending == nil || pending.ScheduleType != models.ReminderScheduleMonthly || pending.EntityName == "" {
+			return renderSchedulePrompt(bot, userID, ctx.Message(), bot.Replies.ReminderSelectTypeFirst)
+		}
+		val, err := strconv.Atoi(strings.TrimSpace(ctx.Data()))
+		if err != nil || val < 1 || val > 31 {
+			return renderMonthDayPrompt(bot, userID, ctx.Message(), bot.Replies.ReminderMonthDayInvalid)
+		}
+		day := int8(val)
+		pending.MonthDay = &day
+		bot.ReminderService.SetPending(userID, pending)
+		return renderTimePrompt(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createCalendarDayHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		pending := bot.ReminderService.GetPending(userID)
+		if pending == nil || pending.ScheduleType != models.ReminderScheduleOnce || pending.EntityName == "" {
+			return renderSchedulePrompt(bot, userID, ctx.Message(), bot.Replies.ReminderSelectTypeFirst)
+		}
+		loc := safeUserLoc(bot, userID)
+		date, err := parseCalendarDay(ctx.Data(), loc)
+		if err != nil {
+			return renderOncePrompt(bot, userID, ctx.Message(), bot.Replies.ReminderOnceDateInvalid)
+		}
+		return applyOnceDate(bot, userID, pending, date, loc, ctx.Message())
+	}
+}
+
+func createCalendarNavHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		pending := bot.ReminderService.GetPending(userID)
+		if pending == nil || pending.ScheduleType != models.ReminderScheduleOnce || pending.EntityName == "" {
+			return renderSchedulePrompt(bot, userID, ctx.Message(), bot.Replies.ReminderSelectTypeFirst)
+		}
+		loc := safeUserLoc(bot, userID)
+		month, err := parseCalendarMonth(ctx.Data(), loc)
+		if err != nil {
+			return renderOncePrompt(bot, userID, ctx.Message(), "")
+		}
+		return renderOnceCalendar(bot, userID, ctx.Message(), "", month)
+	}
+}
+
+func createHourSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		pending := bot.ReminderService.GetPending(userID)
+		if !isAwaitingReminderTime(pending) {
+			return renderSchedulePrompt(bot, userID, ctx.Message(), bot.Replies.ReminderSelectTypeFirst)
+		}
+		hour, err := parsePickerHour(ctx.Data())
+		if err != nil {
+			return renderTimePrompt(bot, userID, ctx.Message(), bot.Replies.ReminderTimeFormatError)
+		}
+		return renderMinutePrompt(bot, userID, ctx.Message(), hour)
+	}
+}
+
+func createMinuteSelectHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		pending := bot.ReminderService.GetPending(userID)
+		if !isAwaitingReminderTime(pending) {
+			return renderSchedulePrompt(bot, userID, ctx.Message(), bot.Replies.ReminderSelectTypeFirst)
+		}
+		minutes, err := parsePickerMinutes(ctx.Data())
+		if err != nil {
+			return renderTimePrompt(bot, userID, ctx.Message(), bot.Replies.ReminderTimeFormatError)
+		}
+		return applyReminderTime(bot, userID, pending, minutes, safeUserLoc(bot, userID))
+	}
+}
+
+func createHoursBackHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		if !isAwaitingReminderTime(bot.ReminderService.GetPending(userID)) {
+			return renderSchedulePrompt(bot, userID, ctx.Message(), bot.Replies.ReminderSelectTypeFirst)
+		}
+		return renderTimePrompt(bot, userID, ctx.Message(), "")
+	}
+}
+
 func createDeleteReminderSelectHandler(bot *b.Bot) telebot.HandlerFunc {
 	return func(ctx telebot.Context) error {
 		userID := ctx.Chat().ID
@@ -233,6 +343,7 @@ func createDeleteReminderSelectHandler(bot *b.Bot) telebot.HandlerFunc {
 			return renderReminderBox(bot, userID, ctx.Message(), "")
 		}
 
+		name := reminderNameByID(bot, userID, reminderID)
 		if err := bot.ReminderService.Delete(reminderID, userID); err != nil {
 			if errors.Is(err, reminder.ErrReminderNotFound) {
 				bot.Fsm.UserEvent(context.Background(), userID, fsmManager.RemindersMenuOpenedEvent)
@@ -242,11 +353,17 @@ func createDeleteReminderSelectHandler(bot *b.Bot) telebot.HandlerFunc {
 		}
 
 		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.RemindersMenuOpenedEvent)
-		return renderReminderBox(bot, userID, ctx.Message(), "")
+		note := fmt.Sprintf(bot.Replies.ReminderDeletedUndo, name, undoWindowMinutes())
+		return renderReminderBoxWithUndo(bot, userID, ctx.Message(), note, reminderID)
 	}
 }
 
 func renderReminderBox(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	return renderReminderBoxWithUndo(bot, userID, sourceMsg, note, 0)
+}
+
+// renderReminderBoxWithUndo renders the reminder box; a non-zero undoReminderID adds an undo button for that deletion.
+func renderReminderBoxWithUndo(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string, undoReminderID uint) error {
 	user := bot.UserService.GetUser(userID)
 	reminders, err := bot.ReminderService.GetList(userID)
 	if err != nil {
@@ -263,7 +380,11 @@ func renderReminderBox(bot *b.Bot, userID int64, sourceMsg *telebot.Message, not
 		var builder strings.Builder
 		builder.WriteString(fmt.Sprintf(bot.Replies.RemindersMenuHeader, status))
 		for _, r := range reminders {
-			builder.WriteString(fmt.Sprintf(bot.Replies.RemindersMenuItemRow, r.Name, helpers.HumanReminderSchedule(r, loc, bot.Replies)))
+			schedule := helpers.HumanReminderSchedule(r, loc, bot.Replies)
+			if r.AlwaysFire {
+				schedule += bot.Replies.ReminderAlwaysFireMark
+			}
+			builder.WriteString(fmt.Sprintf(bot.Replies.RemindersMenuItemRow, r.Name, schedule))
 		}
 		builder.WriteString(bot.Replies.RemindersMenuFooter)
 		text = builder.String()
@@ -272,7 +393,11 @@ func renderReminderBox(bot *b.Bot, userID int64, sourceMsg *telebot.Message, not
 	if note != "" {
 		text = note + "\n\n" + text
 	}
-	return upsertReminderLastMessage(bot, userID, sourceMsg, text, reminderBoxMarkup())
+	markup := reminderBoxMarkup()
+	if undoReminderID != 0 {
+		prependUndoRow(markup, btnUndoReminderDelete, undoReminderID)
+	}
+	return upsertReminderLastMessage(bot, userID, sourceMsg, text, markup)
 }
 
 func renderDeleteReminderSelect(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
@@ -301,6 +426,7 @@ func reminderBoxMarkup() *telebot.ReplyMarkup {
 	markup := &telebot.ReplyMarkup{}
 	markup.Inline(
 		markup.Row(btnAddReminder, btnDeleteReminder),
+		markup.Row(btnReminderExclusions, btnReminderAlwaysFire),
 		markup.Row(btnCloseReminderBox),
 	)
 	return markup
@@ -340,6 +466,25 @@ func weekdayMarkup() *telebot.ReplyMarkup {
 	return markup
 }
 
+func monthDayMarkup() *telebot.ReplyMarkup {
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, 6)
+	row := make([]telebot.Btn, 0, constants.DaysInWeek)
+	for day := 1; day <= 31; day++ {
+		row = append(row, markup.Data(strconv.Itoa(day), btnSelectMonthDay.Unique, strconv.Itoa(day)))
+		if len(row) == constants.DaysInWeek {
+			rows = append(rows, markup.Row(row...))
+			row = make([]telebot.Btn, 0, constants.DaysInWeek)
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, markup.Row(row...))
+	}
+	rows = append(rows, markup.Row(btnBackToReminderBox))
+	markup.Inline(rows...)
+	return markup
+}
+
 func selectReminderMarkup(reminders []models.Reminder) *telebot.ReplyMarkup {
 	markup := &telebot.ReplyMarkup{}
 	if len(reminders) > 0 {
@@ -517,24 +662,28 @@ func handleScheduleSpecificStep(bot *b.Bot, userID int64, pending *session.Pendi
 		if pending.OnceDate != nil {
 			return false, nil
 		}
-		now := time.Now().In(loc)
-		date, err := helpers.ParseDateDM(raw, now, loc)
+		date, err := helpers.ParseDateDM(raw, time.Now().In(loc), loc)
 		if err != nil {
 			return true, renderOncePrompt(bot, userID, nil, bot.Replies.ReminderOnceDateInvalid)
 		}
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
-		dateMidnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
-		if dateMidnight.Before(today) {
-			return true, renderOncePrompt(bot, userID, nil, bot.Replies.ReminderOnceDatePast)
-		}
-		pending.OnceDate = &date
-		bot.ReminderService.SetPending(userID, pending)
-		return true, renderTimePrompt(bot, userID, nil, "")
+		return true, applyOnceDate(bot, userID, pending, date, loc, nil)
 	}
 
 	return false, nil
 }
 
+func applyOnceDate(bot *b.Bot, userID int64, pending *session.PendingReminder, date time.Time, loc *time.Location, sourceMsg *telebot.Message) error {
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	dateMidnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	if dateMidnight.Before(today) {
+		return renderOncePrompt(bot, userID, sourceMsg, bot.Replies.ReminderOnceDatePast)
+	}
+	pending.OnceDate = &dateMidnight
+	bot.ReminderService.SetPending(userID, pending)
+	return renderTimePrompt(bot, userID, sourceMsg, "")
+}
+
 func handleTimeStep(bot *b.Bot, userID int64, pending *session.PendingReminder, raw string, loc *time.Location) (bool, error) {
 	if pending.ScheduleType == models.ReminderScheduleInterval || pending.TimeOfDayMinutes != nil {
 		return false, nil
@@ -542,25 +691,47 @@ func handleTimeStep(bot *b.Bot, userID int64, pending *session.PendingReminder,
 
 	minutes, err := helpers.ParseTimeHM(raw)
 	if err != nil {
-		text := bot.Replies.ReminderTimeFormatError + "\n\n" + bot.Replies.ReminderTimePrompt
-		return true, upsertReminderLastMessage(bot, userID, bot.ReminderService.GetBotLastMsg(userID), text, backToReminderBoxMarkup())
+		return true, renderTimePrompt(bot, userID, bot.ReminderService.GetBotLastMsg(userID), bot.Replies.ReminderTimeFormatError)
 	}
 
+	return true, applyReminderTime(bot, userID, pending, minutes, loc)
+}
+
+// applyReminderTime stores the time of day (clamped to the active window) and finalizes the reminder.
+func applyReminderTime(bot *b.Bot, userID int64, pending *session.PendingReminder, minutes int, loc *time.Location) error {
 	user := bot.UserService.GetUser(userID)
 	adjusted, clamped := reminder.ClampMinutesToWindow(minutes, int(user.DayStart), int(user.DayEnd))
 	min := int16(adjusted)
 	pending.TimeOfDayMinutes = &min
 	bot.ReminderService.SetPending(userID, pending)
 
+	note := ""
 	if clamped {
-		note := fmt.Sprintf("Вне окна %s–%s, поставил на %s",
+		note = fmt.Sprintf("Вне окна %s–%s, поставил на %s",
 			helpers.FormatTimeHM(int(user.DayStart)),
 			helpers.FormatTimeHM(int(user.DayEnd)),
 			helpers.FormatTimeHM(adjusted))
-		return true, finalizeReminder(bot, userID, pending, note, loc)
 	}
+	return finalizeReminder(bot, userID, pending, note, loc)
+}
 
-	return false, nil
+// isAwaitingReminderTime reports whether the wizard has collected everything except the time of day.
+func isAwaitingReminderTime(pending *session.PendingReminder) bool {
+	if pending == nil || pending.EntityName == "" || pending.TimeOfDayMinutes != nil {
+		return false
+	}
+	switch pending.ScheduleType {
+	case models.ReminderScheduleDaily:
+		return true
+	case models.ReminderScheduleWeekly:
+		return pending.Weekday != nil
+	case models.ReminderScheduleMonthly:
+		return pending.MonthDay != nil
+	case models.ReminderScheduleOnce:
+		return pending.OnceDate != nil
+	default:
+		return false
+	}
 }
 func renderSchedulePrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
 	text := bot.Replies.ReminderSchedulePrompt
@@ -591,7 +762,7 @@ func renderMonthDayPrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message,
 	if note != "" {
 		text = note + "\n\n" + text
 	}
-	return upsertReminderLastMessage(bot, userID, sourceMsg, text, backToReminderBoxMarkup())
+	return upsertReminderLastMessage(bot, userID, sourceMsg, text, monthDayMarkup())
 }
 
 func renderIntervalPrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
@@ -603,11 +774,17 @@ func renderIntervalPrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message,
 }
 
 func renderOncePrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	return renderOnceCalendar(bot, userID, sourceMsg, note, time.Now().In(safeUserLoc(bot, userID)))
+}
+
+func renderOnceCalendar(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string, month time.Time) error {
 	text := bot.Replies.ReminderOnceDatePrompt
 	if note != "" {
 		text = note + "\n\n" + text
 	}
-	return upsertReminderLastMessage(bot, userID, sourceMsg, text, backToReminderBoxMarkup())
+	today := time.Now().In(safeUserLoc(bot, userID))
+	markup := calendarMarkup(month, today, btnReminderCalendarDay, btnReminderCalendarNav, telebot.Row{btnBackToReminderBox})
+	return upsertReminderLastMessage(bot, userID, sourceMsg, text, markup)
 }
 
 func renderTimePrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
@@ -615,5 +792,11 @@ func renderTimePrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note
 	if note != "" {
 		text = note + "\n\n" + text
 	}
-	return upsertReminderLastMessage(bot, userID, sourceMsg, text, backToReminderBoxMarkup())
+	return upsertReminderLastMessage(bot, userID, sourceMsg, text, hourPickerMarkup(btnReminderHour, telebot.Row{btnBackToReminderBox}))
+}
+
+func renderMinutePrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, hour int) error {
+	text := fmt.Sprintf(bot.Replies.ReminderMinutePrompt, hour)
+	markup := minutePickerMarkup(hour, btnReminderMinute, btnReminderHoursBack, telebot.Row{btnBackToReminderBox})
+	return upsertReminderLastMessage(bot, userID, sourceMsg, text, markup)
 }
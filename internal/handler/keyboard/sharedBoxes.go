@@ -0,0 +1,549 @@
+package keyboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/feat/items"
+	fsmManager "safeboxtgbot/internal/fsm"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/internal/middleware/auth"
+	"safeboxtgbot/models"
+	"strconv"
+	"strings"
+
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	btnSharedBoxes            = telebot.Btn{Unique: "btn_shared_boxes", Text: "👥 Общие"}
+	btnCreateSharedBox        = telebot.Btn{Unique: "btn_create_shared_box", Text: "➕ Создать"}
+	btnJoinSharedBox          = telebot.Btn{Unique: "btn_join_shared_box", Text: "🔑 Вступить по коду"}
+	btnSelectSharedBox        = telebot.Btn{Unique: "btn_select_shared_box"}
+	btnAddSharedItem          = telebot.Btn{Unique: "btn_add_shared_item", Text: "➕ Добавить вещь"}
+	btnRemoveSharedItem       = telebot.Btn{Unique: "btn_remove_shared_item", Text: "🗑 Убрать вещь"}
+	btnSelectSharedItem       = telebot.Btn{Unique: "btn_select_shared_item"}
+	btnToggleSharedBoxMute    = telebot.Btn{Unique: "btn_toggle_shared_box_mute"}
+	btnSharedBoxMembers       = telebot.Btn{Unique: "btn_shared_box_members", Text: "👥 Участники"}
+	btnKickSharedBoxMember    = telebot.Btn{Unique: "btn_kick_shared_box_member"}
+	btnLeaveSharedBox         = telebot.Btn{Unique: "btn_leave_shared_box", Text: "🚪 Выйти"}
+	btnDeleteSharedBox        = telebot.Btn{Unique: "btn_delete_shared_box", Text: "🗑 Удалить шкатулку"}
+	btnConfirmDeleteSharedBox = telebot.Btn{Unique: "btn_confirm_delete_shared_box", Text: "Да, удалить"}
+	btnBackToSharedBoxes      = telebot.Btn{Unique: "btn_back_to_shared_boxes", Text: "⬅️ Назад"}
+	btnBackToSharedBox        = telebot.Btn{Unique: "btn_back_to_shared_box", Text: "⬅️ Назад"}
+)
+
+func MustInitSharedBoxButtons(bot *b.Bot) {
+	bot.Handle(&btnSharedBoxes, createSharedBoxesHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnCreateSharedBox, createCreateSharedBoxHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnJoinSharedBox, createJoinSharedBoxHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSelectSharedBox, createSelectSharedBoxHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnAddSharedItem, createAddSharedItemHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnRemoveSharedItem, createRemoveSharedItemHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSelectSharedItem, createSelectSharedItemHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnToggleSharedBoxMute, createToggleSharedBoxMuteHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSharedBoxMembers, createSharedBoxMembersHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnKickSharedBoxMember, createKickSharedBoxMemberHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnLeaveSharedBox, createLeaveSharedBoxHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnDeleteSharedBox, createDeleteSharedBoxHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnConfirmDeleteSharedBox, createConfirmDeleteSharedBoxHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnBackToSharedBoxes, createBackToSharedBoxesHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnBackToSharedBox, createBackToSharedBoxHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+func createSharedBoxesHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ItemsService.ClearEditingItemID(userID)
+		bot.ItemsService.SetOpenSharedBoxID(userID, 0)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.SharedBoxesOpenedEvent)
+		return renderSharedBoxes(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createCreateSharedBoxHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.AwaitingSharedBoxNameEvent)
+		text := fmt.Sprintf(bot.Replies.SharedBoxNamePrompt, constants.MaxSharedBoxNameLen)
+		return renderSharedBoxPrompt(bot, userID, ctx.Message(), text, btnBackToSharedBoxes, "")
+	}
+}
+
+func createJoinSharedBoxHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.AwaitingSharedBoxCodeEvent)
+		return renderSharedBoxPrompt(bot, userID, ctx.Message(), bot.Replies.SharedBoxCodePrompt, btnBackToSharedBoxes, "")
+	}
+}
+
+func CreateValidateSharedBoxNameHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.MustDelete(ctx.Message())
+		prompt := fmt.Sprintf(bot.Replies.SharedBoxNamePrompt, constants.MaxSharedBoxNameLen)
+
+		box, err := bot.ItemsService.CreateSharedBox(userID, ctx.Message().Text)
+		switch {
+		case errors.Is(err, items.ErrBoxNameEmpty):
+			return renderSharedBoxPrompt(bot, userID, nil, prompt, btnBackToSharedBoxes, bot.Replies.SharedBoxNameEmpty)
+		case errors.Is(err, items.ErrBoxNameTooLong):
+			return renderSharedBoxPrompt(bot, userID, nil, prompt, btnBackToSharedBoxes, bot.Replies.SharedBoxNameTooLong)
+		case err != nil:
+			return handleSharedBoxError(bot, userID, nil, err)
+		}
+
+		bot.ItemsService.SetOpenSharedBoxID(userID, box.ID)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.SharedBoxesOpenedEvent)
+		return renderSharedBox(bot, userID, nil, fmt.Sprintf(bot.Replies.SharedBoxCreated, html.EscapeString(box.Name)))
+	}
+}
+
+func CreateValidateSharedBoxCodeHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.MustDelete(ctx.Message())
+
+		box, err := bot.ItemsService.JoinSharedBox(userID, ctx.Message().Text)
+		switch {
+		case errors.Is(err, items.ErrBoxNotFound):
+			return renderSharedBoxPrompt(bot, userID, nil, bot.Replies.SharedBoxCodePrompt, btnBackToSharedBoxes, bot.Replies.SharedBoxCodeUnknown)
+		case err != nil:
+			return handleSharedBoxError(bot, userID, nil, err)
+		}
+
+		bot.ItemsService.SetOpenSharedBoxID(userID, box.ID)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.SharedBoxesOpenedEvent)
+		return renderSharedBox(bot, userID, nil, fmt.Sprintf(bot.Replies.SharedBoxJoined, html.EscapeString(box.Name)))
+	}
+}
+
+func createSelectSharedBoxHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		boxID, err := strconv.ParseUint(ctx.Data(), 10, 64)
+		if err != nil {
+			return renderSharedBoxes(bot, userID, ctx.Message(), "")
+		}
+		bot.ItemsService.SetOpenSharedBoxID(userID, uint(boxID))
+		return renderSharedBox(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createAddSharedItemHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		member, err := bot.ItemsService.SharedBox(userID, bot.ItemsService.GetOpenSharedBoxID(userID))
+		if err != nil {
+			return handleSharedBoxError(bot, userID, ctx.Message(), err)
+		}
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.AwaitingSharedItemAddEvent)
+		text := fmt.Sprintf(bot.Replies.SharedBoxItemPrompt, html.EscapeString(member.Box.Name))
+		return renderSharedBoxPrompt(bot, userID, ctx.Message(), text, btnBackToSharedBox, "")
+	}
+}
+
+func CreateValidateSharedItemAddHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.MustDelete(ctx.Message())
+		boxID := bot.ItemsService.GetOpenSharedBoxID(userID)
+		member, err := bot.ItemsService.SharedBox(userID, boxID)
+		if err != nil {
+			return handleSharedBoxError(bot, userID, nil, err)
+		}
+		prompt := fmt.Sprintf(bot.Replies.SharedBoxItemPrompt, html.EscapeString(member.Box.Name))
+
+		err = bot.ItemsService.AddSharedBoxItem(userID, boxID, ctx.Message().Text)
+		switch {
+		case errors.Is(err, items.ErrItemNameEmpty):
+			return renderSharedBoxPrompt(bot, userID, nil, prompt, btnBackToSharedBox, bot.Replies.ItemNameEmpty)
+		case errors.Is(err, items.ErrItemNameTooLong):
+			return renderSharedBoxPrompt(bot, userID, nil, prompt, btnBackToSharedBox, bot.Replies.ItemNameTooLong)
+		case errors.Is(err, items.ErrItemDuplicate):
+			return renderSharedBoxPrompt(bot, userID, nil, prompt, btnBackToSharedBox, bot.Replies.ItemDuplicate)
+		case err != nil:
+			return handleSharedBoxError(bot, userID, nil, err)
+		}
+
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.SharedBoxesOpenedEvent)
+		return renderSharedBox(bot, userID, nil, "")
+	}
+}
+
+func createRemoveSharedItemHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		return renderSharedItemRemove(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createSelectSharedItemHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		itemID, err := helpers.ParseItemID(ctx)
+		if err != nil {
+			return renderSharedItemRemove(bot, userID, ctx.Message(), "")
+		}
+		err = bot.ItemsService.RemoveSharedBoxItem(userID, bot.ItemsService.GetOpenSharedBoxID(userID), itemID)
+		switch {
+		case errors.Is(err, items.ErrNotBoxOwner):
+			return renderSharedItemRemove(bot, userID, ctx.Message(), bot.Replies.SharedBoxNotOwner)
+		case err != nil && !errors.Is(err, items.ErrItemNotFound):
+			return handleSharedBoxError(bot, userID, ctx.Message(), err)
+		}
+		return renderSharedItemRemove(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createToggleSharedBoxMuteHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		boxID := bot.ItemsService.GetOpenSharedBoxID(userID)
+		member, err := bot.ItemsService.SharedBox(userID, boxID)
+		if err != nil {
+			return handleSharedBoxError(bot, userID, ctx.Message(), err)
+		}
+		if err := bot.ItemsService.SetSharedBoxMuted(userID, boxID, !member.Muted); err != nil {
+			return handleSharedBoxError(bot, userID, ctx.Message(), err)
+		}
+		return renderSharedBox(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createSharedBoxMembersHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		return renderSharedBoxMembers(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createKickSharedBoxMemberHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		memberID, err := strconv.ParseInt(ctx.Data(), 10, 64)
+		if err != nil {
+			return renderSharedBoxMembers(bot, userID, ctx.Message(), "")
+		}
+		err = bot.ItemsService.KickSharedBoxMember(userID, bot.ItemsService.GetOpenSharedBoxID(userID), memberID)
+		switch {
+		case errors.Is(err, items.ErrNotBoxOwner):
+			return renderSharedBoxMembers(bot, userID, ctx.Message(), bot.Replies.SharedBoxNotOwner)
+		case err != nil && !errors.Is(err, items.ErrBoxNotFound):
+			return handleSharedBoxError(bot, userID, ctx.Message(), err)
+		}
+		return renderSharedBoxMembers(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createLeaveSharedBoxHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		if err := bot.ItemsService.LeaveSharedBox(userID, bot.ItemsService.GetOpenSharedBoxID(userID)); err != nil {
+			return handleSharedBoxError(bot, userID, ctx.Message(), err)
+		}
+		bot.ItemsService.SetOpenSharedBoxID(userID, 0)
+		return renderSharedBoxes(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createDeleteSharedBoxHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		member, err := bot.ItemsService.SharedBox(userID, bot.ItemsService.GetOpenSharedBoxID(userID))
+		if err != nil {
+			return handleSharedBoxError(bot, userID, ctx.Message(), err)
+		}
+		if member.Box.OwnerID != userID {
+			return renderSharedBox(bot, userID, ctx.Message(), bot.Replies.SharedBoxNotOwner)
+		}
+		markup := &telebot.ReplyMarkup{}
+		markup.Inline(markup.Row(btnConfirmDeleteSharedBox, btnBackToSharedBox))
+		text := fmt.Sprintf(bot.Replies.SharedBoxDeleteConfirm, html.EscapeString(member.Box.Name))
+		return upsertBotLastMessage(bot, userID, ctx.Message(), text, markup)
+	}
+}
+
+func createConfirmDeleteSharedBoxHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		if err := bot.ItemsService.DeleteSharedBox(userID, bot.ItemsService.GetOpenSharedBoxID(userID)); err != nil {
+			return handleSharedBoxError(bot, userID, ctx.Message(), err)
+		}
+		bot.ItemsService.SetOpenSharedBoxID(userID, 0)
+		return renderSharedBoxes(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createBackToSharedBoxesHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ItemsService.SetOpenSharedBoxID(userID, 0)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.SharedBoxesOpenedEvent)
+		return renderSharedBoxes(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createBackToSharedBoxHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.SharedBoxesOpenedEvent)
+		return renderSharedBox(bot, userID, ctx.Message(), "")
+	}
+}
+
+func renderSharedBoxes(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	memberships, err := bot.ItemsService.SharedBoxes(userID)
+	if err != nil {
+		bot.Logger.Error(fmt.Sprintf("Error loading shared boxes for userID=%d: %v", userID, err))
+		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, backToItemBoxMarkup())
+	}
+
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, len(memberships)+2)
+	var builder strings.Builder
+	if len(memberships) == 0 {
+		builder.WriteString(bot.Replies.SharedBoxesEmpty)
+	} else {
+		builder.WriteString(bot.Replies.SharedBoxesHeader)
+	}
+	for _, member := range memberships {
+		boxItems, err := bot.ItemsService.SharedBoxItems(userID, member.BoxID)
+		if err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error loading shared box items for userID=%d boxID=%d: %v", userID, member.BoxID, err))
+		}
+		builder.WriteString(fmt.Sprintf(bot.Replies.SharedBoxesRow, html.EscapeString(member.Box.Name), len(boxItems), sharedBoxOwnerMark(bot, member, userID), sharedBoxMutedMark(bot, member)))
+		label := "👥 " + member.Box.Name + sharedBoxMutedMark(bot, member)
+		rows = append(rows, markup.Row(markup.Data(label, btnSelectSharedBox.Unique, strconv.FormatUint(uint64(member.BoxID), 10))))
+	}
+	if len(memberships) < constants.MaxSharedBoxesPerUser {
+		rows = append(rows, markup.Row(btnCreateSharedBox, btnJoinSharedBox))
+	}
+	rows = append(rows, markup.Row(btnBackToItemBox))
+	markup.Inline(rows...)
+
+	text := builder.String()
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func renderSharedBox(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	boxID := bot.ItemsService.GetOpenSharedBoxID(userID)
+	member, err := bot.ItemsService.SharedBox(userID, boxID)
+	if err != nil {
+		return handleSharedBoxError(bot, userID, sourceMsg, err)
+	}
+	members, err := bot.ItemsService.SharedBoxMembers(userID, boxID)
+	if err != nil {
+		return handleSharedBoxError(bot, userID, sourceMsg, err)
+	}
+	boxItems, err := bot.ItemsService.SharedBoxItems(userID, boxID)
+	if err != nil {
+		return handleSharedBoxError(bot, userID, sourceMsg, err)
+	}
+
+	isOwner := member.Box.OwnerID == userID
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(bot.Replies.SharedBoxDetail, html.EscapeString(member.Box.Name), sharedBoxOwnerMark(bot, *member, userID), len(members)))
+	if isOwner {
+		builder.WriteString(fmt.Sprintf(bot.Replies.SharedBoxJoinCode, member.Box.JoinCode))
+	}
+	if member.Muted {
+		builder.WriteString(bot.Replies.SharedBoxMutedNote)
+	}
+	if len(boxItems) == 0 {
+		builder.WriteString(bot.Replies.SharedBoxItemsEmpty)
+	} else {
+		builder.WriteString(bot.Replies.SharedBoxItemsHeader)
+		for _, item := range boxItems {
+			builder.WriteString(bot.Replies.ItemsMenuItemPrefix)
+			builder.WriteString(html.EscapeString(item.Name))
+			builder.WriteString("\n")
+		}
+	}
+
+	muteText := "🔕 Не присылать мне"
+	if member.Muted {
+		muteText = "🔔 Присылать мне"
+	}
+	exitBtn := btnLeaveSharedBox
+	if isOwner {
+		exitBtn = btnDeleteSharedBox
+	}
+	markup := &telebot.ReplyMarkup{}
+	rows := []telebot.Row{markup.Row(btnAddSharedItem)}
+	if len(boxItems) > 0 {
+		rows[0] = markup.Row(btnAddSharedItem, btnRemoveSharedItem)
+	}
+	rows = append(rows,
+		markup.Row(markup.Data(muteText, btnToggleSharedBoxMute.Unique)),
+		markup.Row(btnSharedBoxMembers, exitBtn),
+		markup.Row(btnBackToSharedBoxes),
+	)
+	markup.Inline(rows...)
+
+	text := strings.TrimRight(builder.String(), "\n")
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func renderSharedItemRemove(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	boxID := bot.ItemsService.GetOpenSharedBoxID(userID)
+	member, err := bot.ItemsService.SharedBox(userID, boxID)
+	if err != nil {
+		return handleSharedBoxError(bot, userID, sourceMsg, err)
+	}
+	boxItems, err := bot.ItemsService.SharedBoxItems(userID, boxID)
+	if err != nil {
+		return handleSharedBoxError(bot, userID, sourceMsg, err)
+	}
+
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, len(boxItems)/itemPickerColumns+2)
+	row := make([]telebot.Btn, 0, itemPickerColumns)
+	for _, item := range boxItems {
+		row = append(row, markup.Data(item.Name, btnSelectSharedItem.Unique, strconv.FormatUint(uint64(item.ID), 10)))
+		if len(row) == itemPickerColumns {
+			rows = append(rows, markup.Row(row...))
+			row = make([]telebot.Btn, 0, itemPickerColumns)
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, markup.Row(row...))
+	}
+	rows = append(rows, markup.Row(btnBackToSharedBox))
+	markup.Inline(rows...)
+
+	text := fmt.Sprintf(bot.Replies.SharedBoxRemoveItem, html.EscapeString(member.Box.Name))
+	if len(boxItems) == 0 {
+		text = bot.Replies.ListIsEmpty
+	}
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+// renderSharedBoxMembers lists members; the owner also gets a kick button per member.
+func renderSharedBoxMembers(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	boxID := bot.ItemsService.GetOpenSharedBoxID(userID)
+	member, err := bot.ItemsService.SharedBox(userID, boxID)
+	if err != nil {
+		return handleSharedBoxError(bot, userID, sourceMsg, err)
+	}
+	members, err := bot.ItemsService.SharedBoxMembers(userID, boxID)
+	if err != nil {
+		return handleSharedBoxError(bot, userID, sourceMsg, err)
+	}
+
+	isOwner := member.Box.OwnerID == userID
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, len(members)+1)
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(bot.Replies.SharedBoxMembersHeader, html.EscapeString(member.Box.Name)))
+	for _, m := range members {
+		name := memberDisplayName(bot, m.UserID)
+		mark := ""
+		if m.UserID == member.Box.OwnerID {
+			mark = bot.Replies.SharedBoxOwnerMark
+		}
+		builder.WriteString(fmt.Sprintf(bot.Replies.SharedBoxMemberRow, html.EscapeString(name), mark))
+		if isOwner && m.UserID != userID {
+			rows = append(rows, markup.Row(markup.Data("🚫 "+name, btnKickSharedBoxMember.Unique, strconv.FormatInt(m.UserID, 10))))
+		}
+	}
+	rows = append(rows, markup.Row(btnBackToSharedBox))
+	markup.Inline(rows...)
+
+	text := strings.TrimRight(builder.String(), "\n")
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func renderSharedBoxPrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, text string, backBtn telebot.Btn, note string) error {
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	markup := &telebot.ReplyMarkup{}
+	markup.Inline(markup.Row(backBtn))
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func sharedBoxOwnerMark(bot *b.Bot, member models.SharedBoxMember, userID int64) string {
+	if member.Box.OwnerID == userID {
+		return bot.Replies.SharedBoxOwnerMark
+	}
+	return ""
+}
+
+func sharedBoxMutedMark(bot *b.Bot, member models.SharedBoxMember) string {
+	if member.Muted {
+		return bot.Replies.SharedBoxMutedMark
+	}
+	return ""
+}
+
+// memberDisplayName asks Telegram for the member's name and falls back to the numeric ID.
+func memberDisplayName(bot *b.Bot, userID int64) string {
+	chat, err := bot.ChatByID(userID)
+	if err != nil || chat == nil {
+		return strconv.FormatInt(userID, 10)
+	}
+	switch {
+	case chat.FirstName != "":
+		return strings.TrimSpace(chat.FirstName + " " + chat.LastName)
+	case chat.Username != "":
+		return "@" + chat.Username
+	default:
+		return strconv.FormatInt(userID, 10)
+	}
+}
+
+func handleSharedBoxError(bot *b.Bot, userID int64, sourceMsg *telebot.Message, err error) error {
+	bot.Fsm.UserEvent(context.Background(), userID, fsmManager.SharedBoxesOpenedEvent)
+	switch {
+	case errors.Is(err, items.ErrBoxNotFound):
+		bot.ItemsService.SetOpenSharedBoxID(userID, 0)
+		return renderSharedBoxes(bot, userID, sourceMsg, bot.Replies.SharedBoxGone)
+	case errors.Is(err, items.ErrBoxLimitReached):
+		return renderSharedBoxes(bot, userID, sourceMsg, fmt.Sprintf(bot.Replies.SharedBoxLimitReached, constants.MaxSharedBoxesPerUser))
+	case errors.Is(err, items.ErrBoxFull):
+		return renderSharedBoxes(bot, userID, sourceMsg, bot.Replies.SharedBoxFull)
+	case errors.Is(err, items.ErrAlreadyBoxMember):
+		return renderSharedBoxes(bot, userID, sourceMsg, bot.Replies.SharedBoxAlreadyMember)
+	case errors.Is(err, items.ErrJoinCodeLocked):
+		return renderSharedBoxes(bot, userID, sourceMsg, bot.Replies.SharedBoxCodeLocked)
+	case errors.Is(err, items.ErrBoxItemLimitReached):
+		return renderSharedBox(bot, userID, sourceMsg, bot.Replies.SharedBoxItemLimit)
+	case errors.Is(err, items.ErrNotBoxOwner), errors.Is(err, items.ErrOwnerCannotLeave):
+		return renderSharedBox(bot, userID, sourceMsg, bot.Replies.SharedBoxNotOwner)
+	}
+	bot.Logger.Error(fmt.Sprintf("Error updating shared boxes for userID=%d: %v", userID, err))
+	bot.ItemsService.SetOpenSharedBoxID(userID, 0)
+	return renderSharedBoxes(bot, userID, sourceMsg, bot.Replies.Error)
+}
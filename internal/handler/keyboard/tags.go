@@ -0,0 +1,436 @@
+package keyboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/feat/items"
+	"safeboxtgbot/internal/feat/tags"
+	fsmManager "safeboxtgbot/internal/fsm"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/internal/middleware/auth"
+	"safeboxtgbot/models"
+	"strconv"
+	"strings"
+
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	btnTags             = telebot.Btn{Unique: "btn_tags", Text: "🏷 Категории"}
+	btnGroupItemsByTag  = telebot.Btn{Unique: "btn_group_items_by_tag", Text: "🗂 По категориям"}
+	btnGroupItemsByName = telebot.Btn{Unique: "btn_group_items_by_name", Text: "🔤 По алфавиту"}
+	btnClearTagFilter   = telebot.Btn{Unique: "btn_clear_tag_filter", Text: "✖️ Все вещи"}
+	btnAddTag           = telebot.Btn{Unique: "btn_add_tag", Text: "➕ Новая категория"}
+	btnSelectTag        = telebot.Btn{Unique: "btn_select_tag"}
+	btnToggleTag        = telebot.Btn{Unique: "btn_toggle_tag"}
+	btnTagSlot          = telebot.Btn{Unique: "btn_tag_slot", Text: "🕒 Время"}
+	btnTagSlotStart     = telebot.Btn{Unique: "btn_tag_slot_start"}
+	btnTagSlotEnd       = telebot.Btn{Unique: "btn_tag_slot_end"}
+	btnTagSlotClear     = telebot.Btn{Unique: "btn_tag_slot_clear", Text: "Любое время"}
+	btnTagAssign        = telebot.Btn{Unique: "btn_tag_assign", Text: "🏷 Разложить вещи"}
+	btnTagAssignItem    = telebot.Btn{Unique: "btn_tag_assign_item"}
+	btnTagShowItems     = telebot.Btn{Unique: "btn_tag_show_items", Text: "📂 Вещи категории"}
+	btnDeleteTag        = telebot.Btn{Unique: "btn_delete_tag", Text: "🗑 Удалить"}
+	btnBackToTags       = telebot.Btn{Unique: "btn_back_to_tags", Text: "⬅️ Назад"}
+	btnBackToTag        = telebot.Btn{Unique: "btn_back_to_tag", Text: "⬅️ Назад"}
+)
+
+func MustInitTagButtons(bot *b.Bot) {
+	bot.Handle(&btnTags, createTagsMenuHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnGroupItemsByTag, createGroupItemsHandler(bot, true), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnGroupItemsByName, createGroupItemsHandler(bot, false), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnClearTagFilter, createClearTagFilterHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnAddTag, createAddTagHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnSelectTag, createSelectTagHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnToggleTag, createToggleTagHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnTagSlot, createTagSlotHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnTagSlotStart, createTagSlotStartHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnTagSlotEnd, createTagSlotEndHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnTagSlotClear, createTagSlotClearHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnTagAssign, createTagAssignHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnTagAssignItem, createTagAssignItemHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnTagShowItems, createTagShowItemsHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnDeleteTag, createDeleteTagHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnBackToTags, createBackToTagsHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnBackToTag, createBackToTagHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+func createTagsMenuHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ItemsService.ClearEditingItemID(userID)
+		bot.TagsService.SetSelectedID(userID, 0)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.TagsMenuOpenedEvent)
+		return renderTagsMenu(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createGroupItemsHandler(bot *b.Bot, grouped bool) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.TagsService.SetGrouped(userID, grouped)
+		return renderItemBox(bot, userID, ctx.Message())
+	}
+}
+
+func createClearTagFilterHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.TagsService.SetFilter(userID, 0)
+		return renderItemBox(bot, userID, ctx.Message())
+	}
+}
+
+func createAddTagHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.AwaitingTagAddEvent)
+		return renderAddTagPrompt(bot, userID, ctx.Message(), "")
+	}
+}
+
+func CreateValidateAddTagHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.MustDelete(ctx.Message())
+
+		err := bot.TagsService.Create(userID, ctx.Message().Text)
+		switch {
+		case errors.Is(err, tags.ErrTagNameEmpty):
+			return renderAddTagPrompt(bot, userID, nil, bot.Replies.TagNameEmpty)
+		case errors.Is(err, tags.ErrTagNameTooLong):
+			return renderAddTagPrompt(bot, userID, nil, bot.Replies.TagNameTooLong)
+		case errors.Is(err, tags.ErrTagDuplicate):
+			return renderAddTagPrompt(bot, userID, nil, bot.Replies.TagDuplicate)
+		case errors.Is(err, tags.ErrTagLimitReached):
+			bot.Fsm.UserEvent(context.Background(), userID, fsmManager.TagsMenuOpenedEvent)
+			return renderTagsMenu(bot, userID, nil, bot.Replies.TagsLimitReached)
+		case err != nil:
+			return handleTagError(bot, userID, nil, err)
+		}
+
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.TagsMenuOpenedEvent)
+		return renderTagsMenu(bot, userID, nil, "")
+	}
+}
+
+func createSelectTagHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		tagID, err := strconv.ParseUint(ctx.Data(), 10, 64)
+		if err != nil {
+			return renderTagsMenu(bot, userID, ctx.Message(), "")
+		}
+		bot.TagsService.SetSelectedID(userID, uint(tagID))
+		return renderTagDetail(bot, userID, ctx.Message())
+	}
+}
+
+func createToggleTagHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		tag, err := bot.TagsService.GetByID(userID, bot.TagsService.GetSelectedID(userID))
+		if err != nil {
+			return handleTagError(bot, userID, ctx.Message(), err)
+		}
+		if err := bot.TagsService.SetDisabled(userID, tag.ID, !tag.Disabled); err != nil {
+			return handleTagError(bot, userID, ctx.Message(), err)
+		}
+		return renderTagDetail(bot, userID, ctx.Message())
+	}
+}
+
+func createTagSlotHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.TagsService.SetSlotStart(userID, -1)
+		return renderTagSlotPicker(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createTagSlotStartHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		hour, err := parsePickerHour(ctx.Data())
+		if err != nil {
+			return renderTagSlotPicker(bot, userID, ctx.Message(), "")
+		}
+		bot.TagsService.SetSlotStart(userID, hour)
+		return renderTagSlotPicker(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createTagSlotEndHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		startHour := bot.TagsService.GetSlotStart(userID)
+		endHour, err := parsePickerHour(ctx.Data())
+		if err != nil || startHour < 0 {
+			bot.TagsService.SetSlotStart(userID, -1)
+			return renderTagSlotPicker(bot, userID, ctx.Message(), "")
+		}
+		start := int16(startHour * constants.MinutesInHour)
+		end := int16(endHour * constants.MinutesInHour)
+		err = bot.TagsService.SetSlot(userID, bot.TagsService.GetSelectedID(userID), &start, &end)
+		if errors.Is(err, tags.ErrTagSlotInvalid) {
+			return renderTagSlotPicker(bot, userID, ctx.Message(), bot.Replies.TagSlotInvalid)
+		}
+		if err != nil {
+			return handleTagError(bot, userID, ctx.Message(), err)
+		}
+		return renderTagDetail(bot, userID, ctx.Message())
+	}
+}
+
+func createTagSlotClearHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		if err := bot.TagsService.SetSlot(userID, bot.TagsService.GetSelectedID(userID), nil, nil); err != nil {
+			return handleTagError(bot, userID, ctx.Message(), err)
+		}
+		return renderTagDetail(bot, userID, ctx.Message())
+	}
+}
+
+func createTagAssignHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.ItemsService.ResetPaging(userID)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.TagAssignOpenedEvent)
+		return renderTagAssign(bot, userID, ctx.Message())
+	}
+}
+
+func createTagAssignItemHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		itemID, err := helpers.ParseItemID(ctx)
+		if err != nil {
+			return renderTagAssign(bot, userID, ctx.Message())
+		}
+		err = bot.TagsService.ToggleItem(userID, bot.TagsService.GetSelectedID(userID), itemID)
+		if err != nil && !errors.Is(err, items.ErrItemNotFound) {
+			return handleTagError(bot, userID, ctx.Message(), err)
+		}
+		return renderTagAssign(bot, userID, ctx.Message())
+	}
+}
+
+func createTagShowItemsHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.TagsService.SetFilter(userID, bot.TagsService.GetSelectedID(userID))
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemsMenuOpenedEvent)
+		return renderItemBox(bot, userID, ctx.Message())
+	}
+}
+
+func createDeleteTagHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		if err := bot.TagsService.Delete(userID, bot.TagsService.GetSelectedID(userID)); err != nil {
+			return handleTagError(bot, userID, ctx.Message(), err)
+		}
+		bot.TagsService.SetSelectedID(userID, 0)
+		return renderTagsMenu(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createBackToTagsHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.TagsService.SetSelectedID(userID, 0)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.TagsMenuOpenedEvent)
+		return renderTagsMenu(bot, userID, ctx.Message(), "")
+	}
+}
+
+func createBackToTagHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.TagsService.SetSlotStart(userID, -1)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.TagsMenuOpenedEvent)
+		return renderTagDetail(bot, userID, ctx.Message())
+	}
+}
+
+func renderTagsMenu(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	tagList, err := bot.TagsService.GetList(userID)
+	if err != nil {
+		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, backToItemBoxMarkup())
+	}
+	itemList, err := bot.ItemsService.GetItemList(userID)
+	if err != nil {
+		return upsertBotLastMessage(bot, userID, sourceMsg, bot.Replies.Error, backToItemBoxMarkup())
+	}
+
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, len(tagList)+2)
+	var builder strings.Builder
+	if len(tagList) == 0 {
+		builder.WriteString(bot.Replies.TagsMenuEmpty)
+	} else {
+		builder.WriteString(bot.Replies.TagsMenuHeader)
+	}
+	for _, tag := range tagList {
+		count := len(itemsWithTag(itemList, tag.ID))
+		builder.WriteString(fmt.Sprintf(bot.Replies.TagsMenuRow, html.EscapeString(tag.Name), count, tagStatusLabel(bot, tag), tagSlotLabel(bot, tag)))
+		label := "🏷 " + tag.Name
+		if tag.Disabled {
+			label = "⏸ " + tag.Name
+		}
+		rows = append(rows, markup.Row(markup.Data(label, btnSelectTag.Unique, strconv.FormatUint(uint64(tag.ID), 10))))
+	}
+	if len(tagList) < constants.MaxTagsPerUser {
+		rows = append(rows, markup.Row(btnAddTag))
+	}
+	rows = append(rows, markup.Row(btnBackToItemBox))
+	markup.Inline(rows...)
+
+	text := builder.String()
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func renderAddTagPrompt(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	text := fmt.Sprintf(bot.Replies.TagAddPrompt, constants.MaxTagNameLen)
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	markup := &telebot.ReplyMarkup{}
+	markup.Inline(markup.Row(btnBackToTags))
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func renderTagDetail(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
+	tag, err := bot.TagsService.GetByID(userID, bot.TagsService.GetSelectedID(userID))
+	if err != nil {
+		return handleTagError(bot, userID, sourceMsg, err)
+	}
+	itemList, err := bot.ItemsService.GetItemList(userID)
+	if err != nil {
+		return handleTagError(bot, userID, sourceMsg, err)
+	}
+
+	toggleText := "⏸ Выключить"
+	if tag.Disabled {
+		toggleText = "▶️ Включить"
+	}
+	markup := &telebot.ReplyMarkup{}
+	markup.Inline(
+		markup.Row(markup.Data(toggleText, btnToggleTag.Unique), btnTagSlot),
+		markup.Row(btnTagAssign, btnTagShowItems),
+		markup.Row(btnDeleteTag),
+		markup.Row(btnBackToTags),
+	)
+	text := fmt.Sprintf(bot.Replies.TagDetail, html.EscapeString(tag.Name), len(itemsWithTag(itemList, tag.ID)), tagStatusLabel(bot, *tag), tagSlotLabel(bot, *tag))
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+// renderTagSlotPicker asks for the start hour, then for the end hour of the slot.
+func renderTagSlotPicker(bot *b.Bot, userID int64, sourceMsg *telebot.Message, note string) error {
+	tag, err := bot.TagsService.GetByID(userID, bot.TagsService.GetSelectedID(userID))
+	if err != nil {
+		return handleTagError(bot, userID, sourceMsg, err)
+	}
+
+	footer := (&telebot.ReplyMarkup{}).Row(btnTagSlotClear, btnBackToTag)
+	startHour := bot.TagsService.GetSlotStart(userID)
+	var (
+		text   string
+		markup *telebot.ReplyMarkup
+	)
+	if startHour < 0 {
+		text = fmt.Sprintf(bot.Replies.TagSlotStartPrompt, html.EscapeString(tag.Name))
+		markup = hourPickerMarkup(btnTagSlotStart, footer)
+	} else {
+		start := helpers.FormatTimeHM(startHour * constants.MinutesInHour)
+		text = fmt.Sprintf(bot.Replies.TagSlotEndPrompt, html.EscapeString(tag.Name), start)
+		markup = hourPickerMarkup(btnTagSlotEnd, footer)
+	}
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	return upsertBotLastMessage(bot, userID, sourceMsg, text, markup)
+}
+
+func renderTagAssign(bot *b.Bot, userID int64, sourceMsg *telebot.Message) error {
+	tag, err := bot.TagsService.GetByID(userID, bot.TagsService.GetSelectedID(userID))
+	if err != nil {
+		return handleTagError(bot, userID, sourceMsg, err)
+	}
+	mark := func(item models.Item) string {
+		if item.TagID != nil && *item.TagID == tag.ID {
+			return bot.Replies.TagAssignedMark
+		}
+		return ""
+	}
+	title := fmt.Sprintf(bot.Replies.TagAssignPrompt, html.EscapeString(tag.Name))
+	return renderItemPickerWith(bot, userID, sourceMsg, title, btnTagAssignItem, btnBackToTag, mark)
+}
+
+func tagStatusLabel(bot *b.Bot, tag models.Tag) string {
+	if tag.Disabled {
+		return bot.Replies.TagStatusOff
+	}
+	return bot.Replies.TagStatusOn
+}
+
+func tagSlotLabel(bot *b.Bot, tag models.Tag) string {
+	if tag.SlotStartMinutes == nil || tag.SlotEndMinutes == nil {
+		return bot.Replies.TagSlotAny
+	}
+	return fmt.Sprintf(bot.Replies.TagSlotRange, helpers.FormatTimeHM(int(*tag.SlotStartMinutes)), helpers.FormatTimeHM(int(*tag.SlotEndMinutes)))
+}
+
+func itemsWithTag(itemList []models.Item, tagID uint) []models.Item {
+	tagged := make([]models.Item, 0, len(itemList))
+	for _, item := range itemList {
+		if item.TagID != nil && *item.TagID == tagID {
+			tagged = append(tagged, item)
+		}
+	}
+	return tagged
+}
+
+// orderByTag reorders sorted items into tag groups, untagged last.
+func orderByTag(itemList []models.Item, tagList []models.Tag) []models.Item {
+	order, groups := tags.GroupByTag(itemList, tagList)
+	ordered := make([]models.Item, 0, len(itemList))
+	for _, key := range order {
+		ordered = append(ordered, groups[key]...)
+	}
+	return ordered
+}
+
+func handleTagError(bot *b.Bot, userID int64, sourceMsg *telebot.Message, err error) error {
+	bot.TagsService.SetSelectedID(userID, 0)
+	bot.Fsm.UserEvent(context.Background(), userID, fsmManager.TagsMenuOpenedEvent)
+	if errors.Is(err, tags.ErrTagNotFound) {
+		return renderTagsMenu(bot, userID, sourceMsg, "")
+	}
+	bot.Logger.Error(fmt.Sprintf("Error updating tags for userID=%d: %v", userID, err))
+	return renderTagsMenu(bot, userID, sourceMsg, bot.Replies.Error)
+}
@@ -0,0 +1,75 @@
+package keyboard
+
+import (
+	"fmt"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/helpers"
+	"strconv"
+	"strings"
+
+	"gopkg.in/telebot.v4"
+)
+
+const (
+	timePickerColumns    = 6
+	timePickerMinuteStep = 5
+)
+
+// hourPickerMarkup renders 00..23 hour buttons; the callback data is the hour.
+func hourPickerMarkup(hourBtn telebot.Btn, footer ...telebot.Row) *telebot.ReplyMarkup {
+	markup := &telebot.ReplyMarkup{}
+	hours := constants.MinutesInDay / constants.MinutesInHour
+	rows := make([]telebot.Row, 0, hours/timePickerColumns+len(footer))
+	row := make([]telebot.Btn, 0, timePickerColumns)
+	for h := 0; h < hours; h++ {
+		row = append(row, markup.Data(fmt.Sprintf("%02d", h), hourBtn.Unique, strconv.Itoa(h)))
+		if len(row) == timePickerColumns {
+			rows = append(rows, markup.Row(row...))
+			row = make([]telebot.Btn, 0, timePickerColumns)
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, markup.Row(row...))
+	}
+	rows = append(rows, footer...)
+	markup.Inline(rows...)
+	return markup
+}
+
+// minutePickerMarkup renders HH:MM buttons for the chosen hour; the callback data is minutes of day.
+func minutePickerMarkup(hour int, minuteBtn, hoursBackBtn telebot.Btn, footer ...telebot.Row) *telebot.ReplyMarkup {
+	markup := &telebot.ReplyMarkup{}
+	rows := make([]telebot.Row, 0, constants.MinutesInHour/timePickerMinuteStep/timePickerColumns+1+len(footer))
+	row := make([]telebot.Btn, 0, timePickerColumns)
+	for m := 0; m < constants.MinutesInHour; m += timePickerMinuteStep {
+		minutes := hour*constants.MinutesInHour + m
+		row = append(row, markup.Data(helpers.FormatTimeHM(minutes), minuteBtn.Unique, strconv.Itoa(minutes)))
+		if len(row) == timePickerColumns {
+			rows = append(rows, markup.Row(row...))
+			row = make([]telebot.Btn, 0, timePickerColumns)
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, markup.Row(row...))
+	}
+	rows = append(rows, markup.Row(hoursBackBtn))
+	rows = append(rows, footer...)
+	markup.Inline(rows...)
+	return markup
+}
+
+func parsePickerHour(raw string) (int, error) {
+	hour, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || hour < 0 || hour >= constants.MinutesInDay/constants.MinutesInHour {
+		return 0, fmt.Errorf("invalid hour")
+	}
+	return hour, nil
+}
+
+func parsePickerMinutes(raw string) (int, error) {
+	minutes, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || !helpers.ValidTimeOfDay(minutes) {
+		return 0, fmt.Errorf("invalid minutes")
+	}
+	return minutes, nil
+}
@@ -0,0 +1,103 @@
+package keyboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/feat/items"
+	"safeboxtgbot/internal/feat/reminder"
+	fsmManager "safeboxtgbot/internal/fsm"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/internal/middleware/auth"
+	"strconv"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	btnUndoItemDelete     = telebot.Btn{Unique: "btn_undo_item_delete", Text: "↩️ Вернуть"}
+	btnUndoReminderDelete = telebot.Btn{Unique: "btn_undo_reminder_delete", Text: "↩️ Вернуть"}
+)
+
+func MustInitUndoButtons(bot *b.Bot) {
+	bot.Handle(&btnUndoItemDelete, createUndoItemDeleteHandler(bot), auth.CreateAuthMiddleware(bot))
+	bot.Handle(&btnUndoReminderDelete, createUndoReminderDeleteHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+func createUndoItemDeleteHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.ItemsMenuOpenedEvent)
+		itemID, err := helpers.ParseItemID(ctx)
+		if err != nil {
+			return renderItemBox(bot, userID, ctx.Message())
+		}
+
+		name, err := bot.ItemsService.RestoreItem(userID, itemID, time.Now().UTC())
+		switch {
+		case errors.Is(err, items.ErrUndoExpired):
+			return renderItemBoxWithNote(bot, userID, ctx.Message(), bot.Replies.UndoExpired)
+		case errors.Is(err, items.ErrItemDuplicate):
+			return renderItemBoxWithNote(bot, userID, ctx.Message(), bot.Replies.UndoItemDuplicate)
+		case errors.Is(err, items.ErrItemLimitReached):
+			return renderItemBoxWithNote(bot, userID, ctx.Message(), bot.Replies.ItemsLimitReached)
+		case err != nil:
+			bot.Logger.Error(fmt.Sprintf("Error restoring itemID=%d for userID=%d: %v", itemID, userID, err))
+			return upsertBotLastMessage(bot, userID, ctx.Message(), bot.Replies.Error, itemBoxMarkup())
+		}
+		return renderItemBoxWithNote(bot, userID, ctx.Message(), fmt.Sprintf(bot.Replies.ItemRestored, html.EscapeString(name)))
+	}
+}
+
+func createUndoReminderDeleteHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		bot.RespondSilently(ctx)
+		bot.Fsm.UserEvent(context.Background(), userID, fsmManager.RemindersMenuOpenedEvent)
+		reminderID, err := parseUintData(ctx)
+		if err != nil {
+			return renderReminderBox(bot, userID, ctx.Message(), "")
+		}
+
+		r, err := bot.ReminderService.Restore(reminderID, userID, time.Now().UTC(), safeUserLoc(bot, userID))
+		switch {
+		case errors.Is(err, reminder.ErrUndoExpired):
+			return renderReminderBox(bot, userID, ctx.Message(), bot.Replies.UndoExpired)
+		case errors.Is(err, reminder.ErrReminderDuplicate):
+			return renderReminderBox(bot, userID, ctx.Message(), bot.Replies.UndoReminderDuplicate)
+		case err != nil:
+			bot.Logger.Error(fmt.Sprintf("Error restoring reminderID=%d for userID=%d: %v", reminderID, userID, err))
+			return upsertReminderLastMessage(bot, userID, ctx.Message(), bot.Replies.Error, reminderBoxMarkup())
+		}
+		return renderReminderBox(bot, userID, ctx.Message(), fmt.Sprintf(bot.Replies.ReminderRestored, html.EscapeString(r.Name)))
+	}
+}
+
+// prependUndoRow puts an undo button for the deleted record above the regular menu rows.
+func prependUndoRow(markup *telebot.ReplyMarkup, undoBtn telebot.Btn, id uint) {
+	btn := markup.Data(undoBtn.Text, undoBtn.Unique, strconv.FormatUint(uint64(id), 10))
+	markup.InlineKeyboard = append([][]telebot.InlineButton{{*btn.Inline()}}, markup.InlineKeyboard...)
+}
+
+func undoWindowMinutes() int {
+	return int(constants.DeletionUndoWindow / time.Minute)
+}
+
+// reminderNameByID returns the escaped name of a listed reminder, or an empty string.
+func reminderNameByID(bot *b.Bot, userID int64, reminderID uint) string {
+	list, err := bot.ReminderService.GetList(userID)
+	if err != nil {
+		return ""
+	}
+	for _, r := range list {
+		if r.ID == reminderID {
+			return html.EscapeString(r.Name)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+package message
+
+import (
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/feat/items"
+	"safeboxtgbot/internal/handler/keyboard"
+	"safeboxtgbot/internal/middleware/auth"
+
+	"gopkg.in/telebot.v4"
+)
+
+func initDocumentHandler(bot *b.Bot) {
+	bot.Handle(telebot.OnDocument, createDocumentHandler(bot), auth.CreateAuthMiddleware(bot))
+}
+
+// createDocumentHandler routes uploads by file type: .ics imports reminders, .json/.csv imports items.
+func createDocumentHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		doc := ctx.Message().Document
+		if doc == nil {
+			return nil
+		}
+		if isICSDocument(doc) {
+			return createImportRemindersHandler(bot)(ctx)
+		}
+		if format, ok := items.ImportFormatByName(doc.FileName); ok {
+			return keyboard.CreateImportItemsHandler(bot, format)(ctx)
+		}
+		return ctx.Send(bot.Replies.DocumentUnsupported)
+	}
+}
@@ -0,0 +1,117 @@
+package message
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	b "safeboxtgbot/internal"
+	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/feat/reminder"
+	"safeboxtgbot/internal/helpers"
+	"safeboxtgbot/models"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v4"
+)
+
+func createImportRemindersHandler(bot *b.Bot) telebot.HandlerFunc {
+	return func(ctx telebot.Context) error {
+		userID := ctx.Chat().ID
+		doc := ctx.Message().Document
+		if doc == nil {
+			return nil
+		}
+		if doc.FileSize > constants.MaxICSImportBytes {
+			return ctx.Send(bot.Replies.ICSImportTooLarge)
+		}
+
+		user := bot.UserService.GetUser(userID)
+		if user == nil || user.TelegramID == 0 {
+			return nil
+		}
+		loc, err := helpers.UserLocation(*user)
+		if err != nil {
+			loc = time.UTC
+		}
+		now := time.Now().UTC()
+
+		reader, err := bot.File(&doc.File)
+		if err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error downloading ics for userID=%d: %v", userID, err))
+			return ctx.Send(bot.Replies.ICSImportFailed)
+		}
+		defer reader.Close()
+
+		events, skipped, err := reminder.ParseICS(io.LimitReader(reader, constants.MaxICSImportBytes), now, loc)
+		if err != nil {
+			bot.Logger.Error(fmt.Sprintf("Error parsing ics for userID=%d: %v", userID, err))
+			return ctx.Send(bot.Replies.ICSImportFailed)
+		}
+		overLimit := 0
+		if len(events) > constants.MaxICSImportEvents {
+			overLimit = len(events) - constants.MaxICSImportEvents
+			events = events[:constants.MaxICSImportEvents]
+		}
+
+		imported := 0
+		for _, ev := range events {
+			if ev.Schedule != models.ReminderScheduleInterval && ev.Schedule != models.ReminderScheduleOnce {
+				adjusted, _ := reminder.ClampMinutesToWindow(int(ev.TimeOfDayMinutes), int(user.DayStart), int(user.DayEnd))
+				ev.TimeOfDayMinutes = int16(adjusted)
+			}
+			if _, err := bot.ReminderService.ImportEvent(userID, ev, now, loc); err != nil {
+				skipped = append(skipped, reminder.ICSSkipped{Summary: ev.Summary, Err: err})
+				continue
+			}
+			imported++
+		}
+
+		return ctx.Send(importReport(bot, imported, skipped, overLimit))
+	}
+}
+
+func isICSDocument(doc *telebot.Document) bool {
+	return strings.HasSuffix(strings.ToLower(doc.FileName), ".ics") || strings.EqualFold(doc.MIME, "text/calendar")
+}
+
+// importReport lists the skipped events; the ones past MaxICSImportEvents are only counted.
+func importReport(bot *b.Bot, imported int, skipped []reminder.ICSSkipped, overLimit int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(bot.Replies.ICSImportResult, imported))
+	if len(skipped) == 0 && overLimit == 0 {
+		return sb.String()
+	}
+	sb.WriteString(bot.Replies.ICSImportSkippedHead)
+	for _, s := range skipped {
+		name := s.Summary
+		if name == "" {
+			name = bot.Replies.ICSSkipNoName
+		}
+		sb.WriteString(fmt.Sprintf(bot.Replies.ICSImportSkippedRow, html.EscapeString(name), icsSkipReason(bot, s.Err)))
+	}
+	if overLimit > 0 {
+		sb.WriteString(fmt.Sprintf(bot.Replies.ICSImportOverLimit, overLimit, constants.MaxICSImportEvents))
+	}
+	return sb.String()
+}
+
+func icsSkipReason(bot *b.Bot, err error) string {
+	switch {
+	case errors.Is(err, reminder.ErrICSNoStart):
+		return bot.Replies.ICSSkipNoStart
+	case errors.Is(err, reminder.ErrICSAllDay):
+		return bot.Replies.ICSSkipAllDay
+	case errors.Is(err, reminder.ErrICSUnsupportedRule):
+		return bot.Replies.ICSSkipUnsupported
+	case errors.Is(err, reminder.ErrICSPast):
+		return bot.Replies.ICSSkipPast
+	case errors.Is(err, reminder.ErrReminderDuplicate):
+		return bot.Replies.ICSSkipDuplicate
+	case errors.Is(err, reminder.ErrEmptyEntityName), errors.Is(err, reminder.ErrEntityNameTooLong):
+		return bot.Replies.ICSSkipNameInvalid
+	default:
+		return bot.Replies.ICSSkipInvalid
+	}
+}
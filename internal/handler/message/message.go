@@ -10,6 +10,7 @@ import (
 
 func MustInitMessagesHandler(bot *b.Bot) {
 	bot.Handle(telebot.OnText, createMessageHandler(bot))
+	initDocumentHandler(bot)
 }
 
 func createMessageHandler(bot *b.Bot) telebot.HandlerFunc {
@@ -26,6 +27,22 @@ func createMessageHandler(bot *b.Bot) telebot.HandlerFunc {
 			return keyboard.CreateValidateAddItemHandler(bot)(ctx)
 		case fsmManager.StateAwaitingItemEdit:
 			return keyboard.CreateValidateEditItemHandler(bot)(ctx)
+		case fsmManager.StateItemEditSelectOpened, fsmManager.StateItemDeleteSelectOpened, fsmManager.StateItemPauseSelectOpened,
+			fsmManager.StateItemNoteSelectOpened, fsmManager.StateTagAssignOpened,
+			fsmManager.StateItemStatsSelectOpened, fsmManager.StatePhraseSelectOpened:
+			return keyboard.CreateItemSearchHandler(bot)(ctx)
+		case fsmManager.StateAwaitingPhraseAdd:
+			return keyboard.CreateValidateAddPhraseHandler(bot)(ctx)
+		case fsmManager.StateAwaitingItemNote:
+			return keyboard.CreateValidateItemNoteHandler(bot)(ctx)
+		case fsmManager.StateAwaitingTagAdd:
+			return keyboard.CreateValidateAddTagHandler(bot)(ctx)
+		case fsmManager.StateAwaitingSharedBoxName:
+			return keyboard.CreateValidateSharedBoxNameHandler(bot)(ctx)
+		case fsmManager.StateAwaitingSharedBoxCode:
+			return keyboard.CreateValidateSharedBoxCodeHandler(bot)(ctx)
+		case fsmManager.StateAwaitingSharedItemAdd:
+			return keyboard.CreateValidateSharedItemAddHandler(bot)(ctx)
 		case fsmManager.StateAwaitingReminderAdd:
 			return keyboard.CreateValidateAddReminderHandler(bot)(ctx)
 		default:
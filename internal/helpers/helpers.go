@@ -3,6 +3,7 @@ package helpers
 import (
 	"fmt"
 	"safeboxtgbot/internal/core/constants"
+	"safeboxtgbot/internal/core/modes"
 	"safeboxtgbot/models"
 	"safeboxtgbot/pkg/utils"
 	"strconv"
@@ -13,42 +14,37 @@ import (
 )
 
 func HumanModeName(mode models.UserMode) string {
-	switch mode {
-	case constants.RoflMode:
-		return "Рофл"
-	case constants.CareMode:
-		return "Забота"
-	case constants.CozyMode:
-		return "Уют"
-	default:
-		return "Уют"
+	if meta, ok := modes.Meta(mode); ok {
+		return meta.Name
 	}
+	return modes.Resolve(mode).Name
 }
 
+// ParseMode accepts keys of the configured mode registry and the mix/auto meta modes.
 func ParseMode(raw string) (models.UserMode, bool) {
-	switch models.UserMode(raw) {
-	case constants.RoflMode:
-		return constants.RoflMode, true
-	case constants.CozyMode:
-		return constants.CozyMode, true
-	case constants.CareMode:
-		return constants.CareMode, true
-	default:
-		return "", false
+	if meta, ok := modes.Meta(models.UserMode(raw)); ok {
+		return meta.Key, true
 	}
+	mode, ok := modes.Get(models.UserMode(raw))
+	return mode.Key, ok
+}
+
+// ModeAt resolves mix and auto to the registry mode of a nudge sent at local time.
+func ModeAt(user models.User, local time.Time) models.UserMode {
+	return modes.Pick(user.Mode, user.AutoModeMap, TimeOfDay(local), utils.RandomIndex).Key
 }
 
 func ModeToStyle(mode models.UserMode) string {
-	switch mode {
-	case constants.RoflMode:
-		return "rofl"
-	case constants.CareMode:
-		return "care"
-	case constants.CozyMode:
-		return "cozy"
-	default:
-		return "cozy"
-	}
+	return modes.Resolve(mode).Style
+}
+
+// ModeStylePrompt is the registry prompt fragment for the mode, used when its style has no template file.
+func ModeStylePrompt(mode models.UserMode) string {
+	return modes.Resolve(mode).Prompt
+}
+
+func ModeFallbackEmojis(mode models.UserMode) []string {
+	return modes.Resolve(mode).Emojis
 }
 
 func TimeOfDay(local time.Time) string {
@@ -53,6 +53,28 @@ func FormatMinutesRange(minMinutes, maxMinutes int) string {
 	return fmt.Sprintf("%d–%d мин", minMinutes, maxMinutes)
 }
 
+// FormatGap renders a duration as minutes, hours or days, dropping the smaller unit past a day.
+func FormatGap(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	switch {
+	case minutes < 1:
+		return "<1 мин"
+	case minutes < 60:
+		return fmt.Sprintf("%d мин", minutes)
+	case minutes < 24*60:
+		if minutes%60 == 0 {
+			return fmt.Sprintf("%d ч", minutes/60)
+		}
+		return fmt.Sprintf("%d ч %d мин", minutes/60, minutes%60)
+	default:
+		days, hours := minutes/(24*60), minutes%(24*60)/60
+		if hours == 0 {
+			return fmt.Sprintf("%d дн.", days)
+		}
+		return fmt.Sprintf("%d дн. %d ч", days, hours)
+	}
+}
+
 func UserNotificationRange(user models.User) (int, int) {
 	if preset, ok := constants.NotificationPresets[user.NotificationPreset]; ok {
 		return preset.MinMinutes, preset.MaxMinutes
@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"safeboxtgbot/models"
+
+	"gorm.io/gorm"
+)
+
+type ExcludedDateRepo struct {
+	db *gorm.DB
+}
+
+func NewExcludedDateRepo(db *gorm.DB) *ExcludedDateRepo {
+	return &ExcludedDateRepo{db: db}
+}
+
+func (r *ExcludedDateRepo) GetByUser(userID int64) ([]models.ExcludedDate, error) {
+	var dates []models.ExcludedDate
+	if err := r.db.Where("user_id = ?", userID).
+		Order("date ASC").
+		Find(&dates).Error; err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
+
+func (r *ExcludedDateRepo) Upsert(date *models.ExcludedDate) error {
+	return r.db.Where("user_id = ? AND date = ?", date.UserID, date.Date).
+		FirstOrCreate(date).
+		Error
+}
+
+func (r *ExcludedDateRepo) Delete(userID int64, date string) (bool, error) {
+	res := r.db.Unscoped().Where("user_id = ? AND date = ?", userID, date).Delete(&models.ExcludedDate{})
+	return res.RowsAffected > 0, res.Error
+}
+
+// DeleteBefore removes every user's excluded dates earlier than the given "YYYY-MM-DD" day.
+func (r *ExcludedDateRepo) DeleteBefore(date string) (int64, error) {
+	res := r.db.Unscoped().Where("date < ?", date).Delete(&models.ExcludedDate{})
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,24 @@
+package repo
+
+import (
+	"safeboxtgbot/models"
+	"testing"
+)
+
+func TestExcludedDateDeleteBefore(t *testing.T) {
+	repo := NewExcludedDateRepo(newTestDB(t, &models.ExcludedDate{}))
+	for _, date := range []models.ExcludedDate{{UserID: 1, Date: "2025-04-30"}, {UserID: 2, Date: "2025-05-01"}, {UserID: 1, Date: "2025-05-02"}} {
+		if err := repo.Upsert(&date); err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+	}
+
+	purged, err := repo.DeleteBefore("2025-05-01")
+	if err != nil || purged != 1 {
+		t.Fatalf("DeleteBefore = %d, %v; want 1", purged, err)
+	}
+	left, _ := repo.GetByUser(1)
+	if len(left) != 1 || left[0].Date != "2025-05-02" {
+		t.Fatalf("unexpected dates left: %+v", left)
+	}
+}
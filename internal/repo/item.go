@@ -3,6 +3,7 @@ package repo
 import (
 	"errors"
 	"safeboxtgbot/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -17,7 +18,7 @@ func NewItemRepo(db *gorm.DB) *ItemRepo {
 
 func (r *ItemRepo) TryGet(userID int64, itemID uint) (*models.Item, bool, error) {
 	var item models.Item
-	err := r.db.Where("user_id = ? AND id = ?", userID, itemID).First(&item).Error
+	err := r.db.Where("user_id = ? AND id = ? AND box_id IS NULL", userID, itemID).First(&item).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, false, nil
 	}
@@ -27,30 +28,132 @@ func (r *ItemRepo) TryGet(userID int64, itemID uint) (*models.Item, bool, error)
 	return &item, true, nil
 }
 
+// GetByTelegramID returns the user's personal items; shared box items are read via SharedBoxRepo.
 func (r *ItemRepo) GetByTelegramID(userID int64) ([]models.Item, error) {
 	var items []models.Item
-	if err := r.db.Where("user_id = ?", userID).Find(&items).Error; err != nil {
+	if err := r.db.Where("user_id = ? AND box_id IS NULL", userID).Find(&items).Error; err != nil {
 		return nil, err
 	}
 	return items, nil
 }
 
 func (r *ItemRepo) Upsert(item *models.Item) error {
-	return r.db.Where("user_id = ? AND name = ?", item.UserID, item.Name).
+	return r.db.Where("user_id = ? AND name = ? AND box_id IS NULL", item.UserID, item.Name).
 		Assign(item).
 		FirstOrCreate(item).
 		Error
 }
 
+// CreateBatch inserts items atomically: either all rows are stored or none.
+func (r *ItemRepo) CreateBatch(items []models.Item) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&items).Error
+	})
+}
+
+// ReplaceAll permanently deletes the user's personal items together with their phrases and pooled
+// messages and inserts the given ones in one transaction. Replaced items cannot be restored.
+func (r *ItemRepo) ReplaceAll(userID int64, items []models.Item) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		oldIDs := tx.Unscoped().Model(&models.Item{}).
+			Select("id").
+			Where("user_id = ? AND box_id IS NULL", userID)
+		if err := tx.Unscoped().Where("item_id IN (?)", oldIDs).Delete(&models.ItemPhrase{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("item_id IN (?)", oldIDs).Delete(&models.PooledMessage{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ? AND box_id IS NULL", userID).Delete(&models.Item{}).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.Create(&items).Error
+	})
+}
+
 func (r *ItemRepo) UpdateName(userID int64, itemID uint, newName string) (bool, error) {
 	result := r.db.Model(&models.Item{}).
-		Where("user_id = ? AND id = ?", userID, itemID).
+		Where("user_id = ? AND id = ? AND box_id IS NULL", userID, itemID).
 		Update("name", newName)
 	return result.RowsAffected > 0, result.Error
 }
 
+func (r *ItemRepo) UpdateNote(userID int64, itemID uint, note string) (bool, error) {
+	result := r.db.Model(&models.Item{}).
+		Where("user_id = ? AND id = ? AND box_id IS NULL", userID, itemID).
+		Update("note", note)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *ItemRepo) UpdateTag(userID int64, itemID uint, tagID *uint) (bool, error) {
+	result := r.db.Model(&models.Item{}).
+		Where("user_id = ? AND id = ? AND box_id IS NULL", userID, itemID).
+		Update("tag_id", tagID)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *ItemRepo) UpdatePause(userID int64, itemID uint, paused bool, until *time.Time) (bool, error) {
+	result := r.db.Model(&models.Item{}).
+		Where("user_id = ? AND id = ? AND box_id IS NULL", userID, itemID).
+		Updates(map[string]interface{}{"paused": paused, "paused_until": until})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *ItemRepo) UpdatePhraseShare(userID int64, itemID uint, share int16) (bool, error) {
+	result := r.db.Model(&models.Item{}).
+		Where("user_id = ? AND id = ? AND box_id IS NULL", userID, itemID).
+		Update("phrase_share", share)
+	return result.RowsAffected > 0, result.Error
+}
+
 func (r *ItemRepo) Delete(userID int64, itemID uint) (bool, error) {
-	result := r.db.Where("user_id = ? AND id = ?", userID, itemID).
+	result := r.db.Where("user_id = ? AND id = ? AND box_id IS NULL", userID, itemID).
 		Delete(&models.Item{})
 	return result.RowsAffected > 0, result.Error
 }
+
+// TryGetDeleted returns a personal item soft-deleted at or after since.
+func (r *ItemRepo) TryGetDeleted(userID int64, itemID uint, since time.Time) (*models.Item, bool, error) {
+	var item models.Item
+	err := r.db.Unscoped().
+		Where("user_id = ? AND id = ? AND box_id IS NULL AND deleted_at IS NOT NULL AND deleted_at >= ?", userID, itemID, since).
+		First(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &item, true, nil
+}
+
+func (r *ItemRepo) Restore(userID int64, itemID uint) (bool, error) {
+	result := r.db.Unscoped().Model(&models.Item{}).
+		Where("user_id = ? AND id = ? AND box_id IS NULL AND deleted_at IS NOT NULL", userID, itemID).
+		Update("deleted_at", nil)
+	return result.RowsAffected > 0, result.Error
+}
+
+// PurgeDeleted permanently removes items soft-deleted before the given time together with their
+// phrases and pooled messages.
+func (r *ItemRepo) PurgeDeleted(before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		deletedIDs := tx.Unscoped().Model(&models.Item{}).
+			Select("id").
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+		if err := tx.Unscoped().Where("item_id IN (?)", deletedIDs).Delete(&models.ItemPhrase{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("item_id IN (?)", deletedIDs).Delete(&models.PooledMessage{}).Error; err != nil {
+			return err
+		}
+		res := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&models.Item{})
+		purged = res.RowsAffected
+		return res.Error
+	})
+	return purged, err
+}
@@ -0,0 +1,35 @@
+package repo
+
+import (
+	"safeboxtgbot/models"
+	"testing"
+)
+
+func TestItemReplaceAllDropsDependents(t *testing.T) {
+	db := newTestDB(t, &models.User{}, &models.Item{}, &models.ItemPhrase{}, &models.PooledMessage{})
+	repo := NewItemRepo(db)
+	old := []models.Item{{UserID: 1, Name: "old"}, {UserID: 2, Name: "other"}}
+	if err := db.Create(&old).Error; err != nil {
+		t.Fatalf("create items: %v", err)
+	}
+	for _, item := range old {
+		db.Create(&models.ItemPhrase{UserID: item.UserID, ItemID: item.ID, Text: "p"})
+		db.Create(&models.PooledMessage{UserID: item.UserID, ItemID: item.ID, Mode: "COZY_MODE", ItemName: item.Name, Text: "t"})
+	}
+
+	if err := repo.ReplaceAll(1, []models.Item{{UserID: 1, Name: "new"}}); err != nil {
+		t.Fatalf("ReplaceAll: %v", err)
+	}
+
+	var items []models.Item
+	db.Unscoped().Order("id").Find(&items)
+	if len(items) != 2 || items[0].Name != "other" || items[1].Name != "new" {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+	var phrases, pooled int64
+	db.Unscoped().Model(&models.ItemPhrase{}).Count(&phrases)
+	db.Unscoped().Model(&models.PooledMessage{}).Count(&pooled)
+	if phrases != 1 || pooled != 1 {
+		t.Fatalf("dependents left: %d phrases, %d pooled; want 1 each (user 2)", phrases, pooled)
+	}
+}
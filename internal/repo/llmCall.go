@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"safeboxtgbot/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LLMCallRepo struct {
+	db *gorm.DB
+}
+
+func NewLLMCallRepo(db *gorm.DB) *LLMCallRepo {
+	return &LLMCallRepo{db: db}
+}
+
+func (r *LLMCallRepo) Create(call *models.LLMCall) error {
+	return r.db.Create(call).Error
+}
+
+// LLMUsageRow aggregates the calls of one UTC day, user, provider and model.
+type LLMUsageRow struct {
+	Day              string
+	UserID           int64
+	Provider         string
+	Model            string
+	Calls            int64
+	Failed           int64
+	PromptTokens     int64
+	CompletionTokens int64
+	LatencyMs        int64
+}
+
+// Usage returns the calls made since the given time grouped by day, user, provider and model.
+func (r *LLMCallRepo) Usage(since time.Time) ([]LLMUsageRow, error) {
+	var rows []LLMUsageRow
+	if err := r.db.Model(&models.LLMCall{}).
+		Select(`date(called_at) AS day, user_id, provider, model,
+			COUNT(*) AS calls,
+			SUM(CASE WHEN outcome = ? THEN 0 ELSE 1 END) AS failed,
+			SUM(prompt_tokens) AS prompt_tokens,
+			SUM(completion_tokens) AS completion_tokens,
+			SUM(latency_ms) AS latency_ms`, models.LLMCallOK).
+		Where("called_at >= ?", since.UTC()).
+		Group("day, user_id, provider, model").
+		Order("day DESC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CountSince returns the number of calls and tokens recorded since the given time. called_at
+// is stored as UTC text and SQLite compares it as a string, so since is converted to UTC too.
+func (r *LLMCallRepo) CountSince(since time.Time) (int64, int64, error) {
+	return r.count(r.db.Where("called_at >= ?", since.UTC()))
+}
+
+// CountUserSince is CountSince limited to the calls made for one user.
+func (r *LLMCallRepo) CountUserSince(userID int64, since time.Time) (int64, int64, error) {
+	return r.count(r.db.Where("called_at >= ? AND user_id = ?", since.UTC(), userID))
+}
+
+func (r *LLMCallRepo) count(query *gorm.DB) (int64, int64, error) {
+	var totals struct {
+		Calls  int64
+		Tokens int64
+	}
+	if err := query.Model(&models.LLMCall{}).
+		Select("COUNT(*) AS calls, COALESCE(SUM(prompt_tokens + completion_tokens), 0) AS tokens").
+		Scan(&totals).Error; err != nil {
+		return 0, 0, err
+	}
+	return totals.Calls, totals.Tokens, nil
+}
@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"safeboxtgbot/models"
+	"testing"
+	"time"
+)
+
+func TestLLMCallCountSinceInOtherZone(t *testing.T) {
+	repo := NewLLMCallRepo(newTestDB(t, &models.LLMCall{}))
+	msk := time.FixedZone("MSK", 3*60*60)
+	midnight := time.Date(2025, time.May, 2, 0, 0, 0, 0, msk)
+	calls := []models.LLMCall{
+		{CalledAt: midnight.Add(-time.Minute).UTC(), UserID: 1, PromptTokens: 100},                         // yesterday in MSK
+		{CalledAt: midnight.Add(90 * time.Minute).UTC(), UserID: 1, PromptTokens: 10, CompletionTokens: 5}, // 01:30 MSK, still May 1 in UTC
+		{CalledAt: midnight.Add(5 * time.Hour).UTC(), UserID: 2, PromptTokens: 20},
+	}
+	for i := range calls {
+		if err := repo.Create(&calls[i]); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	count, tokens, err := repo.CountSince(midnight)
+	if err != nil || count != 2 || tokens != 35 {
+		t.Fatalf("CountSince = %d calls, %d tokens, %v; want 2, 35", count, tokens, err)
+	}
+	count, tokens, err = repo.CountUserSince(1, midnight)
+	if err != nil || count != 1 || tokens != 15 {
+		t.Fatalf("CountUserSince = %d calls, %d tokens, %v; want 1, 15", count, tokens, err)
+	}
+}
@@ -40,6 +40,30 @@ func (r *MessageLogRepo) GetByTelegramID(userID int64) ([]models.MessageLog, err
 	return logs, nil
 }
 
+// ItemLogStats aggregates the nudges sent about one item; the times are Unix seconds.
+type ItemLogStats struct {
+	ItemID    uint
+	Count     int64
+	FirstSent int64
+	LastSent  int64
+}
+
+// StatsByItem counts the user's nudges per item. Times are compared as Unix seconds because
+// the stored text keeps the offset it was written with.
+func (r *MessageLogRepo) StatsByItem(userID int64) ([]ItemLogStats, error) {
+	var rows []ItemLogStats
+	if err := r.db.Model(&models.MessageLog{}).
+		Select(`item_id, COUNT(*) AS count,
+			MIN(CAST(strftime('%s', sent_at) AS INTEGER)) AS first_sent,
+			MAX(CAST(strftime('%s', sent_at) AS INTEGER)) AS last_sent`).
+		Where("user_id = ?", userID).
+		Group("item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
 func (r *MessageLogRepo) GetByUserAndItem(userID int64, itemID uint) ([]models.MessageLog, error) {
 	var logs []models.MessageLog
 	if err := r.db.Where("user_id = ? AND item_id = ?", userID, itemID).
@@ -60,3 +84,17 @@ func (r *MessageLogRepo) GetRecentItemIDs(userID int64, since time.Time) ([]uint
 	}
 	return ids, nil
 }
+
+// GetRecentTexts returns the texts of the last limit nudges about the item, newest first.
+func (r *MessageLogRepo) GetRecentTexts(userID int64, itemID uint, limit int) ([]string, error) {
+	var texts []string
+	if err := r.db.Model(&models.MessageLog{}).
+		Where("user_id = ? AND item_id = ?", userID, itemID).
+		Order("sent_at DESC").
+		Limit(limit).
+		Pluck("text", &texts).
+		Error; err != nil {
+		return nil, err
+	}
+	return texts, nil
+}
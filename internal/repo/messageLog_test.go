@@ -0,0 +1,38 @@
+package repo
+
+import (
+	"safeboxtgbot/models"
+	"testing"
+	"time"
+)
+
+func TestMessageLogStatsByItem(t *testing.T) {
+	repo := NewMessageLogRepo(newTestDB(t, &models.MessageLog{}))
+	base := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	msk := time.FixedZone("MSK", 3*60*60)
+	logs := []models.MessageLog{
+		{UserID: 1, ItemID: 1, SentAt: base, Text: "a"},
+		{UserID: 1, ItemID: 1, SentAt: base.Add(2 * time.Hour).In(msk), Text: "b"}, // stored with its +03:00 offset
+		{UserID: 1, ItemID: 1, SentAt: base.Add(time.Hour), Text: "c"},
+		{UserID: 1, ItemID: 2, SentAt: base, Text: "d"},
+		{UserID: 2, ItemID: 1, SentAt: base.Add(5 * time.Hour), Text: "e"},
+	}
+	for i := range logs {
+		if err := repo.Create(&logs[i]); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	rows, err := repo.StatsByItem(1)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	byItem := make(map[uint]ItemLogStats, len(rows))
+	for _, row := range rows {
+		byItem[row.ItemID] = row
+	}
+	want := ItemLogStats{ItemID: 1, Count: 3, FirstSent: base.Unix(), LastSent: base.Add(2 * time.Hour).Unix()}
+	if len(byItem) != 2 || byItem[1] != want || byItem[2].Count != 1 {
+		t.Fatalf("unexpected stats: %+v", rows)
+	}
+}
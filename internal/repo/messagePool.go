@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"errors"
+	"safeboxtgbot/models"
+
+	"gorm.io/gorm"
+)
+
+type MessagePoolRepo struct {
+	db *gorm.DB
+}
+
+func NewMessagePoolRepo(db *gorm.DB) *MessagePoolRepo {
+	return &MessagePoolRepo{db: db}
+}
+
+func (r *MessagePoolRepo) Create(message *models.PooledMessage) error {
+	return r.db.Create(message).Error
+}
+
+// Pop removes and returns the oldest text for the item and time of day in any of the given modes
+// that still matches the item's name and note.
+func (r *MessagePoolRepo) Pop(userID int64, item models.Item, modes []models.UserMode, timeOfDay string) (*models.PooledMessage, bool, error) {
+	var message models.PooledMessage
+	found := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND item_id = ? AND mode IN ? AND time_of_day = ? AND item_name = ? AND item_note = ?",
+			userID, item.ID, modes, timeOfDay, item.Name, item.Note).
+			Order("id ASC").
+			First(&message).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		res := tx.Unscoped().Delete(&models.PooledMessage{}, message.ID)
+		found = res.RowsAffected > 0
+		return res.Error
+	})
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return &message, true, nil
+}
+
+// CountByItem returns how many texts are buffered per item for the time of day in any of the modes.
+func (r *MessagePoolRepo) CountByItem(userID int64, modes []models.UserMode, timeOfDay string) (map[uint]int, error) {
+	var rows []struct {
+		ItemID uint
+		Count  int
+	}
+	if err := r.db.Model(&models.PooledMessage{}).
+		Select("item_id, COUNT(*) AS count").
+		Where("user_id = ? AND mode IN ? AND time_of_day = ?", userID, modes, timeOfDay).
+		Group("item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.ItemID] = row.Count
+	}
+	return counts, nil
+}
+
+// DeleteStale drops the user's texts generated for modes the user no longer uses, for items that
+// left the rotation, or for an item name or note that has changed since.
+func (r *MessagePoolRepo) DeleteStale(userID int64, modes []models.UserMode, items []models.Item) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		scope := tx.Unscoped().Where("user_id = ?", userID)
+		if len(items) == 0 {
+			return scope.Delete(&models.PooledMessage{}).Error
+		}
+		ids := make([]uint, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		if err := scope.Where("mode NOT IN ? OR item_id NOT IN ?", modes, ids).Delete(&models.PooledMessage{}).Error; err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := tx.Unscoped().
+				Where("user_id = ? AND item_id = ? AND (item_name <> ? OR item_note <> ?)", userID, item.ID, item.Name, item.Note).
+				Delete(&models.PooledMessage{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *MessagePoolRepo) DeleteByUser(userID int64) error {
+	return r.db.Unscoped().Where("user_id = ?", userID).Delete(&models.PooledMessage{}).Error
+}
@@ -0,0 +1,29 @@
+package repo
+
+import (
+	"safeboxtgbot/models"
+	"testing"
+)
+
+func TestMessagePoolPopAnyMode(t *testing.T) {
+	repo := NewMessagePoolRepo(newTestDB(t, &models.PooledMessage{}))
+	item := models.Item{Name: "чай"}
+	item.ID = 7
+	for _, mode := range []models.UserMode{"ROFL_MODE", "CARE_MODE"} {
+		if err := repo.Create(&models.PooledMessage{UserID: 1, ItemID: 7, Mode: mode, TimeOfDay: "morning", ItemName: "чай", Text: string(mode)}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	counts, err := repo.CountByItem(1, []models.UserMode{"COZY_MODE", "CARE_MODE"}, "morning")
+	if err != nil || counts[7] != 1 {
+		t.Fatalf("CountByItem = %v, %v; want 1 for item 7", counts, err)
+	}
+	message, ok, err := repo.Pop(1, item, []models.UserMode{"COZY_MODE", "CARE_MODE"}, "morning")
+	if err != nil || !ok || message.Mode != "CARE_MODE" {
+		t.Fatalf("Pop = %+v, %v, %v; want the CARE_MODE text", message, ok, err)
+	}
+	if _, ok, _ := repo.Pop(1, item, []models.UserMode{"COZY_MODE", "CARE_MODE"}, "morning"); ok {
+		t.Fatal("expected a miss once the matching text was taken")
+	}
+}
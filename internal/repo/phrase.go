@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"safeboxtgbot/models"
+
+	"gorm.io/gorm"
+)
+
+type PhraseRepo struct {
+	db *gorm.DB
+}
+
+func NewPhraseRepo(db *gorm.DB) *PhraseRepo {
+	return &PhraseRepo{db: db}
+}
+
+func (r *PhraseRepo) GetByItem(userID int64, itemID uint) ([]models.ItemPhrase, error) {
+	var phrases []models.ItemPhrase
+	if err := r.db.Where("user_id = ? AND item_id = ?", userID, itemID).
+		Order("id ASC").
+		Find(&phrases).Error; err != nil {
+		return nil, err
+	}
+	return phrases, nil
+}
+
+func (r *PhraseRepo) Create(phrase *models.ItemPhrase) error {
+	return r.db.Create(phrase).Error
+}
+
+func (r *PhraseRepo) Delete(userID int64, itemID uint, phraseID uint) (bool, error) {
+	res := r.db.Unscoped().
+		Where("user_id = ? AND item_id = ? AND id = ?", userID, itemID, phraseID).
+		Delete(&models.ItemPhrase{})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *PhraseRepo) MarkUsed(phraseID uint) error {
+	return r.db.Model(&models.ItemPhrase{}).
+		Where("id = ?", phraseID).
+		Update("used", true).Error
+}
+
+// ResetCycle marks every phrase of the item as unused so the pool can be walked again.
+func (r *PhraseRepo) ResetCycle(userID int64, itemID uint) error {
+	return r.db.Model(&models.ItemPhrase{}).
+		Where("user_id = ? AND item_id = ?", userID, itemID).
+		Update("used", false).Error
+}
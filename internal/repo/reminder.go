@@ -88,7 +88,45 @@ func (r *ReminderRepo) SetEnabled(id uint, userID int64, enabled bool) error {
 		Error
 }
 
+func (r *ReminderRepo) SetAlwaysFire(id uint, userID int64, alwaysFire bool, next time.Time) error {
+	return r.db.Model(&models.Reminder{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(map[string]interface{}{
+			"always_fire": alwaysFire,
+			"next_run":    next,
+		}).
+		Error
+}
+
 func (r *ReminderRepo) Delete(id uint, userID int64) (bool, error) {
 	res := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.Reminder{})
 	return res.RowsAffected > 0, res.Error
 }
+
+// TryGetDeleted returns a reminder soft-deleted at or after since.
+func (r *ReminderRepo) TryGetDeleted(id uint, userID int64, since time.Time) (*models.Reminder, bool, error) {
+	var n models.Reminder
+	err := r.db.Unscoped().
+		Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL AND deleted_at >= ?", id, userID, since).
+		First(&n).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &n, true, nil
+}
+
+// Restore un-deletes a reminder and stores its recomputed next run.
+func (r *ReminderRepo) Restore(id uint, userID int64, next time.Time) (bool, error) {
+	res := r.db.Unscoped().Model(&models.Reminder{}).
+		Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).
+		Updates(map[string]interface{}{"deleted_at": nil, "next_run": next})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *ReminderRepo) PurgeDeleted(before time.Time) (int64, error) {
+	res := r.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&models.Reminder{})
+	return res.RowsAffected, res.Error
+}
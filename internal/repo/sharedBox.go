@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"errors"
+	"safeboxtgbot/models"
+
+	"gorm.io/gorm"
+)
+
+type SharedBoxRepo struct {
+	db *gorm.DB
+}
+
+func NewSharedBoxRepo(db *gorm.DB) *SharedBoxRepo {
+	return &SharedBoxRepo{db: db}
+}
+
+// Create stores the box and its owner membership in one transaction.
+func (r *SharedBoxRepo) Create(box *models.SharedBox) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(box).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.SharedBoxMember{BoxID: box.ID, UserID: box.OwnerID}).Error
+	})
+}
+
+func (r *SharedBoxRepo) TryGetByJoinCode(code string) (*models.SharedBox, bool, error) {
+	var box models.SharedBox
+	err := r.db.Where("join_code = ?", code).First(&box).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &box, true, nil
+}
+
+// TryGetMembership returns the user's membership with the box preloaded.
+func (r *SharedBoxRepo) TryGetMembership(boxID uint, userID int64) (*models.SharedBoxMember, bool, error) {
+	var member models.SharedBoxMember
+	err := r.db.Preload("Box").Where("box_id = ? AND user_id = ?", boxID, userID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &member, true, nil
+}
+
+func (r *SharedBoxRepo) GetMembershipsByUser(userID int64) ([]models.SharedBoxMember, error) {
+	var members []models.SharedBoxMember
+	if err := r.db.Preload("Box").
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *SharedBoxRepo) GetMembers(boxID uint) ([]models.SharedBoxMember, error) {
+	var members []models.SharedBoxMember
+	if err := r.db.Where("box_id = ?", boxID).
+		Order("created_at ASC").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddMemberCapped counts the box members and stores the new membership in one transaction;
+// it returns false without inserting when the box already has limit members.
+func (r *SharedBoxRepo) AddMemberCapped(member *models.SharedBoxMember, limit int64) (bool, error) {
+	added := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.SharedBoxMember{}).Where("box_id = ?", member.BoxID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= limit {
+			return nil
+		}
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		added = true
+		return nil
+	})
+	return added, err
+}
+
+// RemoveMember hard-deletes the membership so the user can join again later.
+func (r *SharedBoxRepo) RemoveMember(boxID uint, userID int64) (bool, error) {
+	res := r.db.Unscoped().Where("box_id = ? AND user_id = ?", boxID, userID).Delete(&models.SharedBoxMember{})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *SharedBoxRepo) UpdateMemberMuted(boxID uint, userID int64, muted bool) (bool, error) {
+	res := r.db.Model(&models.SharedBoxMember{}).
+		Where("box_id = ? AND user_id = ?", boxID, userID).
+		Update("muted", muted)
+	return res.RowsAffected > 0, res.Error
+}
+
+// Delete removes the box with its members and items in one transaction.
+func (r *SharedBoxRepo) Delete(boxID uint) (bool, error) {
+	deleted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("box_id = ?", boxID).Delete(&models.Item{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("box_id = ?", boxID).Delete(&models.SharedBoxMember{}).Error; err != nil {
+			return err
+		}
+		res := tx.Unscoped().Where("id = ?", boxID).Delete(&models.SharedBox{})
+		deleted = res.RowsAffected > 0
+		return res.Error
+	})
+	return deleted, err
+}
+
+func (r *SharedBoxRepo) GetItems(boxID uint) ([]models.Item, error) {
+	var items []models.Item
+	if err := r.db.Where("box_id = ?", boxID).Order("name ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetRotationItems returns the items of every box the user belongs to and has not muted.
+func (r *SharedBoxRepo) GetRotationItems(userID int64) ([]models.Item, error) {
+	var items []models.Item
+	if err := r.db.Joins("JOIN shared_box_members ON shared_box_members.box_id = items.box_id").
+		Where("shared_box_members.user_id = ? AND shared_box_members.muted = ?", userID, false).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *SharedBoxRepo) CreateItem(item *models.Item) error {
+	return r.db.Create(item).Error
+}
+
+func (r *SharedBoxRepo) DeleteItem(boxID uint, itemID uint) (bool, error) {
+	res := r.db.Where("box_id = ? AND id = ?", boxID, itemID).Delete(&models.Item{})
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,27 @@
+package repo
+
+import (
+	"safeboxtgbot/models"
+	"testing"
+)
+
+func TestSharedBoxAddMemberCapped(t *testing.T) {
+	repo := NewSharedBoxRepo(newTestDB(t, &models.SharedBox{}, &models.SharedBoxMember{}))
+	box := &models.SharedBox{OwnerID: 1, Name: "дом", JoinCode: "ABCD2345"}
+	if err := repo.Create(box); err != nil {
+		t.Fatalf("create box: %v", err)
+	}
+
+	added, err := repo.AddMemberCapped(&models.SharedBoxMember{BoxID: box.ID, UserID: 2}, 2)
+	if err != nil || !added {
+		t.Fatalf("AddMemberCapped = %v, %v; want added", added, err)
+	}
+	added, err = repo.AddMemberCapped(&models.SharedBoxMember{BoxID: box.ID, UserID: 3}, 2)
+	if err != nil || added {
+		t.Fatalf("AddMemberCapped = %v, %v; want the full box to refuse", added, err)
+	}
+	members, _ := repo.GetMembers(box.ID)
+	if len(members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(members))
+	}
+}
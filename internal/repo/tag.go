@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"safeboxtgbot/models"
+
+	"gorm.io/gorm"
+)
+
+type TagRepo struct {
+	db *gorm.DB
+}
+
+func NewTagRepo(db *gorm.DB) *TagRepo {
+	return &TagRepo{db: db}
+}
+
+func (r *TagRepo) GetByUser(userID int64) ([]models.Tag, error) {
+	var tags []models.Tag
+	if err := r.db.Where("user_id = ?", userID).
+		Order("name ASC").
+		Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (r *TagRepo) Create(tag *models.Tag) error {
+	return r.db.Create(tag).Error
+}
+
+func (r *TagRepo) UpdateDisabled(userID int64, tagID uint, disabled bool) (bool, error) {
+	res := r.db.Model(&models.Tag{}).
+		Where("user_id = ? AND id = ?", userID, tagID).
+		Update("disabled", disabled)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *TagRepo) UpdateSlot(userID int64, tagID uint, start, end *int16) (bool, error) {
+	res := r.db.Model(&models.Tag{}).
+		Where("user_id = ? AND id = ?", userID, tagID).
+		Updates(map[string]interface{}{"slot_start_minutes": start, "slot_end_minutes": end})
+	return res.RowsAffected > 0, res.Error
+}
+
+// Delete removes the tag and detaches its items in one transaction.
+func (r *TagRepo) Delete(userID int64, tagID uint) (bool, error) {
+	deleted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Item{}).
+			Where("user_id = ? AND tag_id = ?", userID, tagID).
+			Update("tag_id", nil).Error; err != nil {
+			return err
+		}
+		res := tx.Unscoped().Where("user_id = ? AND id = ?", userID, tagID).Delete(&models.Tag{})
+		deleted = res.RowsAffected > 0
+		return res.Error
+	})
+	return deleted, err
+}
@@ -77,6 +77,20 @@ func (r *UserRepo) UpdateMode(telegramID int64, mode models.UserMode) error {
 		Error
 }
 
+func (r *UserRepo) UpdateAutoModeMap(telegramID int64, autoMap string) error {
+	return r.db.Model(&models.User{}).
+		Where("telegram_id = ?", telegramID).
+		Update("auto_mode_map", autoMap).
+		Error
+}
+
+func (r *UserRepo) UpdateHolidayCalendar(telegramID int64, code string) error {
+	return r.db.Model(&models.User{}).
+		Where("telegram_id = ?", telegramID).
+		Update("holiday_calendar", code).
+		Error
+}
+
 func (r *UserRepo) UpdateNotificationInterval(telegramID int64, preset string, min, max int16, next time.Time) error {
 	return r.db.Model(&models.User{}).
 		Where("telegram_id = ?", telegramID).
@@ -21,6 +21,8 @@ type Session struct {
 	Items              ItemsState
 	Daytime            DaytimeState
 	Reminders          RemindersState
+	Exclusions         ExclusionsState
+	Tags               TagsState
 	ExpiresAt          time.Time
 }
 
@@ -28,6 +30,15 @@ type ItemsState struct {
 	EditingItemID uint
 	ItemsLoaded   bool
 	ItemList      []models.Item
+	PendingImport []models.Item // items parsed from an uploaded file, awaiting merge/replace choice
+	Page          int           // current page of the item box or picker
+	Query         string        // substring filter of the edit/delete pickers
+	TagFilter     uint          // item box shows only this tag's items; 0 = all
+	GroupByTag    bool          // item box groups by tag instead of first letter
+	SharedBoxID   uint          // shared box open in the shared box screens
+	Suggestions   []string      // LLM item ideas shown on the suggestions screen
+	JoinFailures  int           // unknown join codes entered since the last lockout
+	JoinLockUntil time.Time     // join codes are refused until then
 }
 
 type DaytimeState struct {
@@ -40,6 +51,18 @@ type RemindersState struct {
 	List    []models.Reminder
 }
 
+type ExclusionsState struct {
+	Loaded bool
+	Dates  []models.ExcludedDate
+}
+
+type TagsState struct {
+	Loaded     bool
+	List       []models.Tag
+	SelectedID uint // tag open in the tag detail, assignment and slot screens
+	SlotStart  int  // start hour picked in the slot picker; -1 = none yet
+}
+
 type PendingReminder struct {
 	EntityName       string
 	ScheduleType     models.ReminderSchedule
@@ -179,6 +202,99 @@ func (store *Store) ClearEditingItemID(userID int64) {
 	})
 }
 
+func (store *Store) GetItemsPage(userID int64) int {
+	return store.Get(userID).Items.Page
+}
+
+func (store *Store) SetItemsPage(userID int64, page int) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.Page = page
+	})
+}
+
+func (store *Store) GetItemsQuery(userID int64) string {
+	return store.Get(userID).Items.Query
+}
+
+func (store *Store) SetItemsQuery(userID int64, query string) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.Query = query
+		sess.Items.Page = 0
+	})
+}
+
+func (store *Store) ResetItemsPaging(userID int64) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.Page = 0
+		sess.Items.Query = ""
+	})
+}
+
+func (store *Store) GetSharedBoxID(userID int64) uint {
+	return store.Get(userID).Items.SharedBoxID
+}
+
+func (store *Store) SetSharedBoxID(userID int64, boxID uint) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.SharedBoxID = boxID
+	})
+}
+
+func (store *Store) GetJoinCodeLockedUntil(userID int64) time.Time {
+	session := store.Get(userID)
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return session.Items.JoinLockUntil
+}
+
+// RegisterJoinCodeFailure counts an unknown join code and locks join attempts for lockout
+// once maxAttempts failures were reached.
+func (store *Store) RegisterJoinCodeFailure(userID int64, maxAttempts int, lockout time.Duration, now time.Time) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.JoinFailures++
+		if sess.Items.JoinFailures >= maxAttempts {
+			sess.Items.JoinFailures = 0
+			sess.Items.JoinLockUntil = now.Add(lockout)
+		}
+	})
+}
+
+func (store *Store) ResetJoinCodeFailures(userID int64) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.JoinFailures = 0
+		sess.Items.JoinLockUntil = time.Time{}
+	})
+}
+
+func (store *Store) GetItemSuggestions(userID int64) []string {
+	session := store.Get(userID)
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return session.Items.Suggestions
+}
+
+func (store *Store) SetItemSuggestions(userID int64, suggestions []string) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.Suggestions = suggestions
+	})
+}
+
+func (store *Store) GetPendingItemImport(userID int64) []models.Item {
+	return store.Get(userID).Items.PendingImport
+}
+
+func (store *Store) SetPendingItemImport(userID int64, items []models.Item) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.PendingImport = items
+	})
+}
+
+func (store *Store) ClearPendingItemImport(userID int64) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.PendingImport = nil
+	})
+}
+
 func (store *Store) GetDayStartSelection(userID int64) int {
 	return store.Get(userID).Daytime.StartMinutes
 }
@@ -234,6 +350,85 @@ func (store *Store) ClearReminders(userID int64) {
 	})
 }
 
+func (store *Store) IsExclusionsLoaded(userID int64) bool {
+	return store.Get(userID).Exclusions.Loaded
+}
+
+func (store *Store) GetExcludedDates(userID int64) []models.ExcludedDate {
+	session := store.Get(userID)
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return session.Exclusions.Dates
+}
+
+func (store *Store) SetExcludedDates(userID int64, dates []models.ExcludedDate) {
+	store.Update(userID, func(sess *Session) {
+		sess.Exclusions.Dates = dates
+		sess.Exclusions.Loaded = true
+	})
+}
+
+func (store *Store) IsTagsLoaded(userID int64) bool {
+	return store.Get(userID).Tags.Loaded
+}
+
+func (store *Store) GetTagList(userID int64) []models.Tag {
+	session := store.Get(userID)
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return session.Tags.List
+}
+
+func (store *Store) SetTagList(userID int64, tags []models.Tag) {
+	store.Update(userID, func(sess *Session) {
+		sess.Tags.List = tags
+		sess.Tags.Loaded = true
+	})
+}
+
+func (store *Store) GetSelectedTagID(userID int64) uint {
+	return store.Get(userID).Tags.SelectedID
+}
+
+func (store *Store) SetSelectedTagID(userID int64, tagID uint) {
+	store.Update(userID, func(sess *Session) {
+		sess.Tags.SelectedID = tagID
+		sess.Tags.SlotStart = -1
+	})
+}
+
+func (store *Store) GetTagSlotStart(userID int64) int {
+	return store.Get(userID).Tags.SlotStart
+}
+
+func (store *Store) SetTagSlotStart(userID int64, hour int) {
+	store.Update(userID, func(sess *Session) {
+		sess.Tags.SlotStart = hour
+	})
+}
+
+func (store *Store) GetItemsTagFilter(userID int64) uint {
+	return store.Get(userID).Items.TagFilter
+}
+
+func (store *Store) SetItemsTagFilter(userID int64, tagID uint) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.TagFilter = tagID
+		sess.Items.Page = 0
+	})
+}
+
+func (store *Store) IsItemsGroupedByTag(userID int64) bool {
+	return store.Get(userID).Items.GroupByTag
+}
+
+func (store *Store) SetItemsGroupedByTag(userID int64, grouped bool) {
+	store.Update(userID, func(sess *Session) {
+		sess.Items.GroupByTag = grouped
+		sess.Items.Page = 0
+	})
+}
+
 // Delete removes a session explicitly.
 func (store *Store) Delete(userID int64) {
 	store.mu.Lock()
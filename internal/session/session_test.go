@@ -334,3 +334,25 @@ func TestCleanupExpired_SkipsAuthorized(t *testing.T) {
 		t.Fatalf("expected authorized session to be preserved")
 	}
 }
+
+func TestStoreJoinCodeFailures_LockAfterMaxAttempts(t *testing.T) {
+	logger := &testLogger{}
+	store := NewStore(time.Minute, logger)
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	_ = store.Get(5)
+	for i := 0; i < 2; i++ {
+		store.RegisterJoinCodeFailure(5, 3, time.Hour, now)
+	}
+	if got := store.GetJoinCodeLockedUntil(5); !got.IsZero() {
+		t.Fatalf("expected no lock before the third failure, got %v", got)
+	}
+	store.RegisterJoinCodeFailure(5, 3, time.Hour, now)
+	if got := store.GetJoinCodeLockedUntil(5); !got.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected lock until %v, got %v", now.Add(time.Hour), got)
+	}
+	store.ResetJoinCodeFailures(5)
+	if got := store.GetJoinCodeLockedUntil(5); !got.IsZero() {
+		t.Fatalf("expected lock cleared after reset, got %v", got)
+	}
+}
@@ -14,6 +14,12 @@ type Replies struct {
 
 	ChangeModePrompt           string
 	ChangeModeUpdated          string
+	AutoModeSettings           string
+	AutoModeRow                string
+	AutoModeMorning            string
+	AutoModeDay                string
+	AutoModeEvening            string
+	AutoModeNight              string
 	ChangeIntervalPrompt       string
 	ChangeIntervalUpdated      string
 	ToggleNotificationsPrompt  string
@@ -31,12 +37,124 @@ type Replies struct {
 	ItemsMenuEmpty          string
 	ItemsMenuHeader         string
 	ItemsMenuStatus         string
+	ItemsMenuStatusPaused   string
 	ItemsMenuFooter         string
 	ItemsMenuItemPrefix     string
+	ItemsMenuGroupHeader    string
+	ItemsPickerSearchHint   string
+	ItemsSearchResult       string
+	ItemsSearchNothing      string
+	WhatDoWePause           string
+	ItemPausePrompt         string
+	ItemPauseDatePrompt     string
+	ItemPauseTomorrow       string
+	ItemPauseDays           string
+	ItemPauseForever        string
+	ItemPausedMark          string
+	ItemPausedUntilMark     string
+	WhatDoWeNote            string
+	ItemNotePrompt          string
+	ItemNoteCurrent         string
+	ItemNoteTooLong         string
+	ItemNoteMark            string
+	ItemStatsSelect         string
+	ItemStatsEmpty          string
+	ItemStatsMark           string
+	ItemStatsDetail         string
+	ItemStatsNever          string
+	ItemStatsLastSent       string
+	ItemStatsAvgGap         string
+	ItemStatsTextsHeader    string
+	ItemStatsTextRow        string
+	ItemStatsTextsEmpty     string
+	WhatDoWePhrase          string
+	PhraseShareMark         string
+	PhrasesHeader           string
+	PhrasesEmpty            string
+	PhraseRow               string
+	PhraseShareCurrent      string
+	PhraseAdded             string
+	PhraseDeleted           string
+	PhraseEmpty             string
+	PhraseTooLong           string
+	PhraseDuplicate         string
+	PhraseLimitReached      string
+	ItemDeletedUndo         string
+	ItemRestored            string
+	ReminderDeletedUndo     string
+	ReminderRestored        string
+	UndoExpired             string
+	UndoItemDuplicate       string
+	UndoReminderDuplicate   string
+	TagsMenuHeader          string
+	TagsMenuEmpty           string
+	TagsMenuRow             string
+	TagDetail               string
+	TagStatusOn             string
+	TagStatusOff            string
+	TagSlotAny              string
+	TagSlotRange            string
+	TagAddPrompt            string
+	TagNameEmpty            string
+	TagNameTooLong          string
+	TagDuplicate            string
+	TagsLimitReached        string
+	TagSlotStartPrompt      string
+	TagSlotEndPrompt        string
+	TagSlotInvalid          string
+	TagAssignPrompt         string
+	TagAssignedMark         string
+	ItemsTagFilterHeader    string
+	ItemsUntaggedHeader     string
+	ItemSuggestThinking     string
+	ItemSuggestHeader       string
+	ItemSuggestFailed       string
+	SharedBoxesHeader       string
+	SharedBoxesEmpty        string
+	SharedBoxesRow          string
+	SharedBoxOwnerMark      string
+	SharedBoxMutedMark      string
+	SharedBoxDetail         string
+	SharedBoxJoinCode       string
+	SharedBoxMutedNote      string
+	SharedBoxItemsHeader    string
+	SharedBoxItemsEmpty     string
+	SharedBoxNamePrompt     string
+	SharedBoxCodePrompt     string
+	SharedBoxItemPrompt     string
+	SharedBoxNameEmpty      string
+	SharedBoxNameTooLong    string
+	SharedBoxLimitReached   string
+	SharedBoxFull           string
+	SharedBoxCodeUnknown    string
+	SharedBoxCodeLocked     string
+	SharedBoxAlreadyMember  string
+	SharedBoxItemLimit      string
+	SharedBoxNotOwner       string
+	SharedBoxGone           string
+	SharedBoxJoined         string
+	SharedBoxCreated        string
+	SharedBoxRemoveItem     string
+	SharedBoxMembersHeader  string
+	SharedBoxMemberRow      string
+	SharedBoxDeleteConfirm  string
 	ItemsLimitReached       string
 	ItemDuplicate           string
 	ItemNameEmpty           string
 	ItemNameTooLong         string
+	ItemsBulkHeader         string
+	ItemsBulkAdded          string
+	ItemsBulkDuplicate      string
+	ItemsBulkTooLong        string
+	ItemsBulkLimitReached   string
+	ItemsExportEmpty        string
+	ItemsExportCaption      string
+	ItemsImportPrompt       string
+	ItemsImportEmpty        string
+	ItemsImportFailed       string
+	ItemsImportTooLarge     string
+	ItemsImportExpired      string
+	DocumentUnsupported     string
 	ReminderBoxClosed       string
 	RemindersMenuEmpty      string
 	RemindersMenuHeader     string
@@ -53,6 +171,7 @@ type Replies struct {
 	ReminderWeekdayInvalid  string
 	ReminderMonthDayPrompt  string
 	ReminderTimePrompt      string
+	ReminderMinutePrompt    string
 	ReminderTimeFormatError string
 	ReminderScheduleInvalid string
 	ReminderSchedulePrompt  string
@@ -67,6 +186,34 @@ type Replies struct {
 	ReminderHumanWeekly     string
 	ReminderHumanMonthly    string
 	ReminderHumanFallback   string
+	ReminderAlwaysFireMark  string
+
+	ReminderAlwaysFirePrompt string
+	ExclusionsHeader         string
+	ExclusionsEmpty          string
+	ExclusionsItemRow        string
+	ExclusionsHolidaysButton string
+	ExclusionsHolidaysOff    string
+	ExclusionsLimitReached   string
+	ExclusionDatePrompt      string
+	ExclusionDateInvalid     string
+
+	ICSExportEmpty       string
+	ICSExportCaption     string
+	ICSImportTooLarge    string
+	ICSImportFailed      string
+	ICSImportResult      string
+	ICSImportSkippedHead string
+	ICSImportSkippedRow  string
+	ICSImportOverLimit   string
+	ICSSkipNoStart       string
+	ICSSkipAllDay        string
+	ICSSkipUnsupported   string
+	ICSSkipPast          string
+	ICSSkipInvalid       string
+	ICSSkipNoName        string
+	ICSSkipDuplicate     string
+	ICSSkipNameInvalid   string
 }
 
 func NewReplies() *Replies {
@@ -82,6 +229,12 @@ func NewReplies() *Replies {
 
 		ChangeModePrompt:           "Выбери режим (сейчас: \"%s\")",
 		ChangeModeUpdated:          "Режим переключён на \"%s\" ✅",
+		AutoModeSettings:           "Режим \"Авто\" ✅\nСтиль зависит от времени суток. Нажми на строку, чтобы сменить стиль.\n",
+		AutoModeRow:                "\n%s — %s",
+		AutoModeMorning:            "🌅 Утро",
+		AutoModeDay:                "☀️ День",
+		AutoModeEvening:            "🌇 Вечер",
+		AutoModeNight:              "🌙 Ночь",
 		ChangeIntervalPrompt:       "Выбери частоту напоминаний (сейчас: \"%s\")",
 		ChangeIntervalUpdated:      "Частота переключена на \"%s\" (%s) ✅",
 		ToggleNotificationsPrompt:  "Уведомления сейчас %s. Переключить?",
@@ -90,7 +243,7 @@ func NewReplies() *Replies {
 		ChangeDayEndPrompt:         "Выбери конец дня (начало: %s):",
 		ChangeDayUpdated:           "Готово ✨\nЯ буду писать с %s до %s",
 
-		AddNewItem:          "✍️ Напиши новую вещь 👇",
+		AddNewItem:          "✍️ Напиши новую вещь 👇\nМожно сразу несколько: каждую с новой строки или через запятую",
 		WriteNewItemName:    "✏️ Напиши новое имя 👇",
 		NewNameForValue:     "✏️ Новое имя для \"%s\" 👇",
 		WhatDoWeEdit:        "Что изменить?",
@@ -106,6 +259,128 @@ func NewReplies() *Replies {
 		ItemNameEmpty:       "Пустое название. Напиши ещё раз",
 		ItemNameTooLong:     "Слишком длинно. Сократи название",
 
+		ItemsMenuGroupHeader:  "<b>%s</b>\n",
+		ItemsPickerSearchHint: "\nНапиши часть названия, чтобы найти",
+		ItemsSearchResult:     "🔎 «%s» — найдено: <b>%d</b>",
+		ItemsSearchNothing:    "🔎 «%s» — ничего не нашлось",
+
+		ItemsMenuStatusPaused: "Режим: <b>%s</b> • Активных: <b>%d</b> • На паузе: <b>%d</b> • Окно: <b>%s–%s</b>\n",
+		WhatDoWePause:         "Что поставить на паузу?\n⏸ — уже на паузе, нажми, чтобы вернуть",
+		ItemPausePrompt:       "⏸ «%s» — на сколько убрать из напоминаний?",
+		ItemPauseDatePrompt:   "📅 В какой день вернуть «%s»?",
+		ItemPauseTomorrow:     "До завтра",
+		ItemPauseDays:         "%d дн.",
+		ItemPauseForever:      "Без срока",
+		ItemPausedMark:        " ⏸",
+		ItemPausedUntilMark:   " ⏸ до %s",
+
+		WhatDoWeNote:    "К какой вещи написать заметку?\n📝 — заметка уже есть",
+		ItemNotePrompt:  "📝 Заметка к «%s»\nНапиши, что важно знать: какая именно, где, как тебе нравится (до %d символов). Заметка попадёт в текст напоминаний",
+		ItemNoteCurrent: "\n\nСейчас: <i>%s</i>",
+		ItemNoteTooLong: "Слишком длинная заметка. Сократи до %d символов",
+		ItemNoteMark:    " 📝",
+
+		ItemStatsSelect:      "📊 Статистика напоминаний\nВсего отправлено: <b>%d</b>\nВыбери вещь, чтобы посмотреть подробнее\n· N — сколько раз я о ней напоминал",
+		ItemStatsEmpty:       "📊 Я ещё ни о чём не напоминал — статистика появится после первых сообщений\nВыбери вещь, чтобы посмотреть подробнее",
+		ItemStatsMark:        " · %d",
+		ItemStatsDetail:      "📊 «%s»\nНапоминаний: <b>%d</b>",
+		ItemStatsNever:       "\nПро эту вещь я ещё не напоминал",
+		ItemStatsLastSent:    "\nПоследнее: <b>%s</b>",
+		ItemStatsAvgGap:      "\nВ среднем раз в <b>%s</b>",
+		ItemStatsTextsHeader: "💬 Последние тексты про «%s»:\n\n",
+		ItemStatsTextRow:     "<b>%s</b>\n%s\n\n",
+		ItemStatsTextsEmpty:  "💬 Про «%s» я ещё ничего не писал",
+
+		WhatDoWePhrase:     "Для какой вещи настроить свои фразы?\n💬 N% — такая доля напоминаний берётся из твоих фраз",
+		PhraseShareMark:    " 💬 %d%%",
+		PhrasesHeader:      "💬 Фразы для «%s»\nНапиши фразу сообщением (до %d символов) — я добавлю её в набор. Фразы не повторяются, пока не пройдёт весь набор\n\n",
+		PhrasesEmpty:       "Фраз пока нет\n",
+		PhraseRow:          "%d. %s\n",
+		PhraseShareCurrent: "\nИз фраз: <b>%d%%</b> напоминаний, остальное пишет нейросеть. Если она не ответит, я тоже возьму фразу",
+		PhraseAdded:        "✅ Фраза добавлена",
+		PhraseDeleted:      "🗑 Фраза удалена",
+		PhraseEmpty:        "Пустую фразу не добавить",
+		PhraseTooLong:      "Слишком длинная фраза. Сократи до %d символов",
+		PhraseDuplicate:    "Такая фраза уже есть",
+		PhraseLimitReached: "Больше %d фраз для одной вещи не добавить — удали лишние",
+
+		ItemDeletedUndo:       "🗑 «%s» удалена. Передумал? Вернуть можно в течение %d мин",
+		ItemRestored:          "↩️ «%s» снова в коробке",
+		ReminderDeletedUndo:   "🗑 Напоминание «%s» удалено. Вернуть можно в течение %d мин",
+		ReminderRestored:      "↩️ Напоминание «%s» вернулось",
+		UndoExpired:           "Время на отмену вышло — удаление уже не вернуть",
+		UndoItemDuplicate:     "Не могу вернуть: в коробке уже есть вещь с таким названием",
+		UndoReminderDuplicate: "Не могу вернуть: уже есть напоминание с таким названием",
+
+		TagsMenuHeader:       "🏷 Категории\nВыключенные категории и категории вне своего времени не попадают в напоминания\n\n",
+		TagsMenuEmpty:        "🏷 Категорий пока нет\nСоздай, например, «здоровье», «еда» или «перерыв», и разложи по ним вещи",
+		TagsMenuRow:          "• <b>%s</b> — %d шт. • %s • %s\n",
+		TagDetail:            "🏷 <b>%s</b>\nВещей: <b>%d</b> • %s • %s",
+		TagStatusOn:          "включена",
+		TagStatusOff:         "выключена",
+		TagSlotAny:           "любое время",
+		TagSlotRange:         "%s–%s",
+		TagAddPrompt:         "✍️ Напиши название категории (до %d символов) 👇",
+		TagNameEmpty:         "Пустое название. Напиши ещё раз",
+		TagNameTooLong:       "Слишком длинно. Сократи название",
+		TagDuplicate:         "Такая категория уже есть. Напиши другую",
+		TagsLimitReached:     "Достигнут лимит категорий. Удали какую-нибудь и попробуй снова",
+		TagSlotStartPrompt:   "🕒 С какого часа напоминать про «%s»?",
+		TagSlotEndPrompt:     "🕒 «%s» с %s — до какого часа?",
+		TagSlotInvalid:       "Начало и конец совпадают. Выбери другой час",
+		TagAssignPrompt:      "🏷 Какие вещи положить в «%s»?\n✅ — уже там, нажми ещё раз, чтобы убрать",
+		TagAssignedMark:      " ✅",
+		ItemsTagFilterHeader: "🏷 Только «%s»\n",
+		ItemsUntaggedHeader:  "Без категории",
+
+		ItemSuggestThinking: "💡 Придумываю идеи…",
+		ItemSuggestHeader:   "💡 Идеи для шкатулки\nНажми, чтобы добавить, ещё раз — чтобы убрать\n✅ — уже в шкатулке",
+		ItemSuggestFailed:   "Не получилось придумать идеи. Попробуй ещё раз чуть позже",
+
+		SharedBoxesHeader:      "👥 Общие шкатулки\nВещи из них приходят в напоминания всем участникам\n\n",
+		SharedBoxesEmpty:       "👥 Общих шкатулок пока нет\nСоздай свою и пригласи семью или команду по коду, или вступи в чужую",
+		SharedBoxesRow:         "• <b>%s</b> — вещей: %d%s%s\n",
+		SharedBoxOwnerMark:     " 👑",
+		SharedBoxMutedMark:     " 🔕",
+		SharedBoxDetail:        "👥 <b>%s</b>%s\nУчастников: <b>%d</b>",
+		SharedBoxJoinCode:      "\nКод для приглашения: <code>%s</code>\nОтправь его тем, кого хочешь позвать: «👥 Общие» → «🔑 Вступить по коду»",
+		SharedBoxMutedNote:     "\n🔕 Ты не получаешь напоминаний из этой шкатулки",
+		SharedBoxItemsHeader:   "\n\nВещи:\n",
+		SharedBoxItemsEmpty:    "\n\nВещей пока нет",
+		SharedBoxNamePrompt:    "✍️ Как назовём общую шкатулку? (до %d символов) 👇",
+		SharedBoxCodePrompt:    "🔑 Напиши код приглашения 👇",
+		SharedBoxItemPrompt:    "✍️ Напиши вещь для «%s» 👇",
+		SharedBoxNameEmpty:     "Пустое название. Напиши ещё раз",
+		SharedBoxNameTooLong:   "Слишком длинно. Сократи название",
+		SharedBoxLimitReached:  "Можно состоять не больше чем в %d общих шкатулках",
+		SharedBoxFull:          "В этой шкатулке уже максимум участников",
+		SharedBoxCodeUnknown:   "Такого кода нет. Проверь и напиши ещё раз",
+		SharedBoxCodeLocked:    "Слишком много неверных кодов. Попробуй позже",
+		SharedBoxAlreadyMember: "Ты уже в этой шкатулке",
+		SharedBoxItemLimit:     "В шкатулке достигнут лимит вещей",
+		SharedBoxNotOwner:      "Это может сделать только владелец шкатулки",
+		SharedBoxGone:          "Этой шкатулки больше нет или тебя из неё убрали",
+		SharedBoxJoined:        "Готово, ты в шкатулке «%s» ✨",
+		SharedBoxCreated:       "Шкатулка «%s» создана ✨",
+		SharedBoxRemoveItem:    "Какую вещь убрать из «%s»?\nУбрать можно свои вещи, а владелец — любые",
+		SharedBoxMembersHeader: "👥 Участники «%s»:\n\n",
+		SharedBoxMemberRow:     "• %s%s\n",
+		SharedBoxDeleteConfirm: "Удалить «%s» вместе со всеми вещами? Участники перестанут получать из неё напоминания",
+
+		ItemsBulkHeader:       "Добавлено: <b>%d</b> из %d\n",
+		ItemsBulkAdded:        "✅ %s\n",
+		ItemsBulkDuplicate:    "♻️ %s — уже есть\n",
+		ItemsBulkTooLong:      "✂️ %s — слишком длинно\n",
+		ItemsBulkLimitReached: "⛔️ %s — лимит вещей\n",
+		ItemsExportEmpty:      "Шкатулка пуста — экспортировать нечего",
+		ItemsExportCaption:    "📦 Твои вещи. Пришли этот файл обратно (можно в другом аккаунте), чтобы импортировать",
+		ItemsImportPrompt:     "📥 В файле вещей: <b>%d</b>\nДобавить их к текущим или заменить всю шкатулку?\nЗамена удаляет текущие вещи с их фразами насовсем — восстановить их будет нельзя.",
+		ItemsImportEmpty:      "В файле нет ни одной вещи",
+		ItemsImportFailed:     "Не получилось прочитать файл. Нужен JSON или CSV из /export_items",
+		ItemsImportTooLarge:   "Файл слишком большой для импорта",
+		ItemsImportExpired:    "Импорт устарел. Пришли файл ещё раз",
+		DocumentUnsupported:   "Могу импортировать .ics с напоминаниями или .json/.csv с вещами",
+
 		ReminderBoxClosed:       "Напоминания закрыты 🔒",
 		RemindersMenuEmpty:      "%s\n" + constants.ReminderPrefix + "Твои напоминания\n\n(пока пусто)\n\nЧто делаем?",
 		RemindersMenuHeader:     "%s\n" + constants.ReminderPrefix + "Твои напоминания:\n\n",
@@ -120,13 +395,14 @@ func NewReplies() *Replies {
 		ReminderIntervalInvalid: "Напиши положительное число минут",
 		ReminderWeekdayPrompt:   "📅 Выбери день недели",
 		ReminderWeekdayInvalid:  "Выбери день недели",
-		ReminderMonthDayPrompt:  "📅 Напиши число месяца (1–31)\nЕсли нужен последний день месяца — введи 31",
+		ReminderMonthDayPrompt:  "📅 Выбери или напиши число месяца (1–31)\nЕсли нужен последний день месяца — выбери 31",
 		ReminderMonthDayInvalid: "День должен быть 1–31",
-		ReminderTimePrompt:      "⌚️ Напиши время в формате HH:MM",
+		ReminderTimePrompt:      "⌚️ Выбери час или напиши время в формате HH:MM",
+		ReminderMinutePrompt:    "⌚️ %02d:__ — выбери минуты или напиши время в формате HH:MM",
 		ReminderTimeFormatError: "Формат HH:MM",
 		ReminderScheduleInvalid: "Неверное расписание. Попробуй снова",
 		ReminderSchedulePrompt:  "Как часто напоминать?",
-		ReminderOnceDatePrompt:  "📅 Напиши дату в формате ДД.ММ",
+		ReminderOnceDatePrompt:  "📅 Выбери дату или напиши в формате ДД.ММ",
 		ReminderOnceDateInvalid: "Дата не подходит. Формат ДД.ММ",
 		ReminderOnceDatePast:    "Дата уже в прошлом. Введи будущую",
 		ReminderOnceTimePast:    "Время уже прошло для выбранной даты",
@@ -136,5 +412,33 @@ func NewReplies() *Replies {
 		ReminderHumanWeekly:     "по %s в %s",
 		ReminderHumanMonthly:    "каждый %d день в %s",
 		ReminderHumanFallback:   "по расписанию",
+		ReminderAlwaysFireMark:  " 🔁",
+
+		ReminderAlwaysFirePrompt: "Отметь напоминания, которые приходят даже в праздники и дни-исключения\n🔁 — приходит всегда, 🚫 — пропускает исключения",
+		ExclusionsHeader:         "🚫 Дни без напоминаний\nПраздники: <b>%s</b>\n\n",
+		ExclusionsEmpty:          "(своих дат нет)\n",
+		ExclusionsItemRow:        "• %s\n",
+		ExclusionsHolidaysButton: "🎌 Праздники: %s",
+		ExclusionsHolidaysOff:    "выкл",
+		ExclusionsLimitReached:   "Достигнут лимит дат. Убери что-то и попробуй снова",
+		ExclusionDatePrompt:      "📅 Выбери день без напоминаний",
+		ExclusionDateInvalid:     "Дата не подходит. Попробуй снова",
+
+		ICSExportEmpty:       "Нечего экспортировать: активных напоминаний нет",
+		ICSExportCaption:     "📤 Напоминания в формате iCalendar. Открой файл в календаре или пришли его обратно, чтобы импортировать",
+		ICSImportTooLarge:    "Файл слишком большой для импорта",
+		ICSImportFailed:      "Не получилось прочитать файл календаря",
+		ICSImportResult:      "📥 Импортировано напоминаний: <b>%d</b>",
+		ICSImportSkippedHead: "\n\nПропущено:\n",
+		ICSImportSkippedRow:  "• %s — %s\n",
+		ICSImportOverLimit:   "• ещё %d событий — за один раз можно импортировать не больше %d\n",
+		ICSSkipNoStart:       "нет даты начала",
+		ICSSkipAllDay:        "событие на весь день, без времени",
+		ICSSkipUnsupported:   "такое правило повтора не поддерживается",
+		ICSSkipPast:          "дата уже прошла",
+		ICSSkipInvalid:       "не подходит расписание",
+		ICSSkipNoName:        "(без названия)",
+		ICSSkipDuplicate:     "такое напоминание уже есть",
+		ICSSkipNameInvalid:   "название пустое или слишком длинное",
 	}
 }
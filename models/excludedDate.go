@@ -0,0 +1,10 @@
+package models
+
+import "gorm.io/gorm"
+
+// ExcludedDate is a user-defined day (holiday, vacation) on which reminders do not fire.
+type ExcludedDate struct {
+	gorm.Model
+	UserID int64  `gorm:"not null;uniqueIndex:idx_excluded_user_date"`
+	Date   string `gorm:"not null;size:10;uniqueIndex:idx_excluded_user_date"` // YYYY-MM-DD in the user's timezone
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	LLMCallOK       = "ok"
+	LLMCallError    = "error"
+	LLMCallRejected = "rejected" // answered, but failed output validation
+)
+
+// LLMCall is one request to an LLM provider, kept for usage and cost reports.
+type LLMCall struct {
+	gorm.Model
+	CalledAt         time.Time `gorm:"not null;index"`
+	UserID           int64     `gorm:"not null;default:0;index"` // 0 when the call was not made for a user
+	Provider         string    `gorm:"not null"`
+	LLMModel         string    `gorm:"column:model;not null;default:''"`
+	Outcome          string    `gorm:"not null"`
+	PromptTokens     int       `gorm:"not null;default:0"`
+	CompletionTokens int       `gorm:"not null;default:0"`
+	LatencyMs        int64     `gorm:"not null;default:0"`
+	Error            string    `gorm:"not null;default:''"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "gorm.io/gorm"
+
+// ItemPhrase is a user-written nudge text for one item, used instead of or mixed with LLM output.
+type ItemPhrase struct {
+	gorm.Model
+	UserID int64  `gorm:"not null;index:idx_phrase_user_item"`
+	ItemID uint   `gorm:"not null;index:idx_phrase_user_item"`
+	Text   string `gorm:"not null"`
+	Used   bool   `gorm:"not null;default:false"` // sent in the current cycle; reset once every phrase was used
+}
@@ -0,0 +1,16 @@
+package models
+
+import "gorm.io/gorm"
+
+// PooledMessage is a nudge text generated ahead of time for one item, mode and time of day.
+// ItemName and ItemNote are the values it was generated for; a mismatch marks the text stale.
+type PooledMessage struct {
+	gorm.Model
+	UserID    int64    `gorm:"not null;index:idx_pool_key"`
+	ItemID    uint     `gorm:"not null;index:idx_pool_key"`
+	Mode      UserMode `gorm:"not null;index:idx_pool_key"`
+	TimeOfDay string   `gorm:"not null;default:'';index:idx_pool_key"`
+	ItemName  string   `gorm:"not null"`
+	ItemNote  string   `gorm:"not null;default:''"`
+	Text      string   `gorm:"not null"`
+}
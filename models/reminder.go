@@ -19,6 +19,7 @@ type Reminder struct {
 	Weekday          *int8            `gorm:"index;check:weekday IS NULL OR (weekday >= 0 AND weekday <= 6)"`
 	MonthDay         *int8            `gorm:"index;check:month_day IS NULL OR (month_day >= 1 AND month_day <= 31)"`
 	Enabled          bool             `gorm:"not null;default:true"`
+	AlwaysFire       bool             `gorm:"not null;default:false"` // ignore user exclusion dates and holidays
 
 	User User `gorm:"foreignKey:UserID;references:ID"`
 }
@@ -0,0 +1,21 @@
+package models
+
+import "gorm.io/gorm"
+
+// SharedBox is an item box several users draw nudges from. Its items are Item rows with BoxID set.
+type SharedBox struct {
+	gorm.Model
+	OwnerID  int64  `gorm:"not null;index"` // Telegram ID of the creator; only the owner may kick or delete
+	Name     string `gorm:"not null"`
+	JoinCode string `gorm:"not null;uniqueIndex"`
+}
+
+// SharedBoxMember links a user to a shared box. The owner is a member too.
+type SharedBoxMember struct {
+	gorm.Model
+	BoxID  uint  `gorm:"not null;uniqueIndex:idx_box_member"`
+	UserID int64 `gorm:"not null;uniqueIndex:idx_box_member;index"`
+	Muted  bool  `gorm:"not null;default:false"` // member opted out of nudges from this box
+
+	Box SharedBox `gorm:"foreignKey:BoxID"`
+}
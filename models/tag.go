@@ -0,0 +1,13 @@
+package models
+
+import "gorm.io/gorm"
+
+// Tag is a user-defined item category that can be switched off or limited to a time slot.
+type Tag struct {
+	gorm.Model
+	UserID           int64  `gorm:"not null;uniqueIndex:idx_tag_user_name"`
+	Name             string `gorm:"not null;uniqueIndex:idx_tag_user_name"`
+	Disabled         bool   `gorm:"not null;default:false"`                                                                      // items of a disabled tag are skipped by the notifier
+	SlotStartMinutes *int16 `gorm:"check:slot_start_minutes IS NULL OR (slot_start_minutes >= 0 AND slot_start_minutes < 1440)"` // nil = no slot restriction
+	SlotEndMinutes   *int16 `gorm:"check:slot_end_minutes IS NULL OR (slot_end_minutes >= 0 AND slot_end_minutes < 1440)"`
+}
@@ -22,14 +22,24 @@ type User struct {
 	NextNotification               time.Time `gorm:"index"`
 	ItemBoxClosedMsgID             int       `gorm:"not null;default:0"`
 	ReminderBoxClosedMsgID         int       `gorm:"not null;default:0"`
+	HolidayCalendar                string    `gorm:"not null;default:''"` // bundled holiday calendar code, empty = off
+	AutoModeMap                    string    `gorm:"not null;default:''"` // AUTO_MODE styles, e.g. "morning=COZY_MODE;day=ROFL_MODE"
 	Items                          []Item
 	Reminders                      []Reminder
 }
 
 type Item struct {
 	gorm.Model
-	UserID int64  `gorm:"not null"`
-	Name   string `gorm:"not null"`
+	UserID      int64      `gorm:"not null"`
+	Name        string     `gorm:"not null"`
+	Note        string     `gorm:"not null;default:''"` // optional user context passed to the LLM
+	TagID       *uint      `gorm:"index"`               // optional category, see Tag
+	BoxID       *uint      `gorm:"index"`               // set for items of a SharedBox; nil = personal item
+	Paused      bool       `gorm:"not null;default:false"`
+	PausedUntil *time.Time // UTC; nil with Paused = paused until resumed manually
+
+	// PhraseShare is the percentage of nudges taken from the item's ItemPhrase pool instead of the LLM.
+	PhraseShare int16 `gorm:"not null;default:0;check:phrase_share >= 0 AND phrase_share <= 100"`
 
 	User User `gorm:"foreignKey:UserID;references:ID"`
 }
@@ -40,4 +50,5 @@ type MessageLog struct {
 	ItemID uint      `gorm:"not null;index"`
 	SentAt time.Time `gorm:"not null;index:idx_user_time"`
 	Text   string    `gorm:"not null"`
+	Style  string    `gorm:"not null;default:''"` // style_mode the text was written in; empty for older rows
 }